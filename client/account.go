@@ -0,0 +1,349 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CreateAuthenticationToken returns a token of the account's user, see [WithAuthenticationToken].
+func (c *Client) CreateAuthenticationToken(ctx context.Context, req *AuthenticationRequest) (*AuthenticationToken, error) {
+	var resp struct {
+		Token *AuthenticationToken `json:"token"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/tokens/authentication",
+		auth:   noAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Token, nil
+}
+
+// CreateAPIKey creates an API key, its plaintext Key is only returned here.
+func (c *Client) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*APIKey, error) {
+	var resp struct {
+		APIKey *APIKey `json:"apiKey"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/api-keys",
+		auth:   tokenAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.APIKey, nil
+}
+
+func (c *Client) GetAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	var resp struct {
+		APIKeys []*APIKey `json:"apiKeys"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/api-keys",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.APIKeys, nil
+}
+
+func (c *Client) GetAPIKey(ctx context.Context, id string) (*APIKey, error) {
+	var resp struct {
+		APIKey *APIKey `json:"apiKey"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/api-keys/" + url.PathEscape(id),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.APIKey, nil
+}
+
+func (c *Client) DeleteAPIKey(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/api-keys/" + url.PathEscape(id),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, nil)
+}
+
+// GetJobRetention returns the days the account's jobs are kept for after they
+// finish, nil when the worker's default retention applies.
+func (c *Client) GetJobRetention(ctx context.Context) (*int, error) {
+	var resp struct {
+		JobRetentionDays *int `json:"job_retention_days"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/retention",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.JobRetentionDays, nil
+}
+
+// UpdateJobRetention overrides the worker's default retention of the account's
+// jobs, a nil JobRetentionDays goes back to the default one.
+func (c *Client) UpdateJobRetention(ctx context.Context, req *UpdateJobRetentionRequest) error {
+	return c.do(ctx, &request{
+		method:     http.MethodPut,
+		path:       "/v1/account/retention",
+		auth:       tokenAuth,
+		body:       req,
+		idempotent: true,
+	}, nil)
+}
+
+// RotateSigningSecret creates a secret the webhooks are signed with, the
+// previous ones expire after the grace period of the request.
+func (c *Client) RotateSigningSecret(ctx context.Context, req *RotateSigningSecretRequest) (*SigningSecret, error) {
+	var resp struct {
+		SigningSecret *SigningSecret `json:"signingSecret"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/account/signing-secrets",
+		auth:   tokenAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SigningSecret, nil
+}
+
+func (c *Client) GetSigningSecrets(ctx context.Context) ([]*SigningSecret, error) {
+	var resp struct {
+		SigningSecrets []*SigningSecret `json:"signingSecrets"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/signing-secrets",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SigningSecrets, nil
+}
+
+func (c *Client) DeleteSigningSecret(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/account/signing-secrets/" + url.PathEscape(id),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, nil)
+}
+
+// GetSecrets returns the account's secrets, without their values.
+func (c *Client) GetSecrets(ctx context.Context) ([]*Secret, error) {
+	var resp struct {
+		Secrets []*Secret `json:"secrets"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/secrets",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Secrets, nil
+}
+
+// PutSecret creates the named secret or replaces its value.
+func (c *Client) PutSecret(ctx context.Context, name string, req *PutSecretRequest) (*Secret, error) {
+	var resp struct {
+		Secret *Secret `json:"secret"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPut,
+		path:       "/v1/account/secrets/" + url.PathEscape(name),
+		auth:       tokenAuth,
+		body:       req,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Secret, nil
+}
+
+func (c *Client) DeleteSecret(ctx context.Context, name string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/account/secrets/" + url.PathEscape(name),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, nil)
+}
+
+func (c *Client) GetSMTPConfig(ctx context.Context) (*SMTPConfig, error) {
+	var resp struct {
+		SMTP *SMTPConfig `json:"smtp"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/smtp",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SMTP, nil
+}
+
+// PutSMTPConfig sets the SMTP server the account's emails are sent through.
+func (c *Client) PutSMTPConfig(ctx context.Context, req *SMTPConfigRequest) (*SMTPConfig, error) {
+	var resp struct {
+		SMTP *SMTPConfig `json:"smtp"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPut,
+		path:       "/v1/account/smtp",
+		auth:       tokenAuth,
+		body:       req,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SMTP, nil
+}
+
+func (c *Client) DeleteSMTPConfig(ctx context.Context) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/account/smtp",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, nil)
+}
+
+// SenderIdentityResponse is a sender identity with the DNS TXT record that verifies it.
+type SenderIdentityResponse struct {
+	SenderIdentity     *SenderIdentity `json:"sender_identity"`
+	VerificationRecord string          `json:"verification_record,omitempty"`
+}
+
+func (c *Client) CreateSenderIdentity(ctx context.Context, req *SenderIdentityRequest) (*SenderIdentityResponse, error) {
+	var resp SenderIdentityResponse
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/account/sender-identities",
+		auth:   tokenAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetSenderIdentities(ctx context.Context) ([]*SenderIdentity, error) {
+	var resp struct {
+		SenderIdentities []*SenderIdentity `json:"sender_identities"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/sender-identities",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SenderIdentities, nil
+}
+
+func (c *Client) GetSenderIdentity(ctx context.Context, id string) (*SenderIdentityResponse, error) {
+	var resp SenderIdentityResponse
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/account/sender-identities/" + url.PathEscape(id),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// VerifySenderIdentity checks the DNS TXT record of the identity, marking it
+// verified when it's found.
+func (c *Client) VerifySenderIdentity(ctx context.Context, id string) (*SenderIdentity, error) {
+	var resp struct {
+		SenderIdentity *SenderIdentity `json:"sender_identity"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPost,
+		path:       "/v1/account/sender-identities/" + url.PathEscape(id) + "/verify",
+		auth:       tokenAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SenderIdentity, nil
+}
+
+func (c *Client) DeleteSenderIdentity(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/account/sender-identities/" + url.PathEscape(id),
+		auth:       tokenAuth,
+		idempotent: true,
+	}, nil)
+}
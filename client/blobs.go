@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// UploadBlob stores the content read from r as a blob, e.g. to attach it to
+// emails. It fails with [ErrTooLarge] when the content exceeds the API limit.
+// It's never retried, as r can't be read twice.
+func (c *Client) UploadBlob(ctx context.Context, filename, contentType string, r io.Reader) (*Blob, error) {
+	var resp struct {
+		Blob *Blob `json:"blob"`
+	}
+
+	err := c.do(ctx, &request{
+		method:      http.MethodPost,
+		path:        "/v1/blobs",
+		query:       url.Values{"filename": {filename}},
+		auth:        apiKeyAuth,
+		rawBody:     r,
+		contentType: contentType,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Blob, nil
+}
+
+func (c *Client) GetBlob(ctx context.Context, id string) (*Blob, error) {
+	var resp struct {
+		Blob *Blob `json:"blob"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/blobs/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Blob, nil
+}
+
+func (c *Client) DeleteBlob(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/blobs/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, nil)
+}
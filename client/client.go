@@ -0,0 +1,287 @@
+// Package client is the Go client of the asyncq REST API.
+//
+// Jobs and the other resources of an account are accessed with an API key,
+// while the API keys themselves and the settings of the account need the
+// authentication token of the account's user:
+//
+//	c := client.New("https://asyncq.example.com", client.WithAPIKey(os.Getenv("ASYNCQ_API_KEY")))
+//
+//	req, err := client.WebhookJob(&client.WebhookPayload{
+//		URL:    "https://example.com/hooks/orders",
+//		Method: http.MethodPost,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	req.Tags = []string{"orders"}
+//
+//	j, err := c.CreateJob(ctx, req)
+//
+// Requests that can be safely sent twice are retried when they fail with a
+// network error or a temporary server error, see [WithRetries]. Jobs are
+// created with an idempotency key, so a retried creation returns the job the
+// first attempt created instead of creating another.
+//
+// Error responses are returned as an [*Error], which matches the sentinel
+// error of its status with [errors.Is], e.g. [ErrNotFound].
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 500 * time.Millisecond
+	// the longest a retry waits for, unless the API asks for longer with Retry-After
+	maxRetryBackoff = 30 * time.Second
+)
+
+// Client calls the asyncq REST API. It's safe for concurrent use.
+type Client struct {
+	baseURL      string
+	apiKey       string
+	token        string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	userAgent    string
+}
+
+type Option func(*Client)
+
+// WithAPIKey sets the API key the jobs and the other resources of the account are accessed with.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithAuthenticationToken sets the token, see [Client.CreateAuthenticationToken],
+// the API keys and the settings of the account are accessed with.
+func WithAuthenticationToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sets the client requests are sent with, [http.DefaultClient] if not set.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a failed request is retried, 0 disables
+// retries, and the delay before the first retry, which doubles on each one.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g. https://asyncq.example.com
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   http.DefaultClient,
+		maxRetries:   DefaultMaxRetries,
+		retryBackoff: DefaultRetryBackoff,
+		userAgent:    "asyncq-go",
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// credentials a request is authenticated with
+type auth int
+
+const (
+	noAuth auth = iota
+	tokenAuth
+	apiKeyAuth
+)
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	auth   auth
+	// encoded as JSON
+	body any
+	// sent as is instead of body, such requests aren't retried as it can't be read twice
+	rawBody     io.Reader
+	contentType string
+	header      http.Header
+	// whether sending the request more than once has the same effect as sending it once
+	idempotent bool
+}
+
+// do sends the request and decodes the JSON response into dst, if given.
+func (c *Client) do(ctx context.Context, req *request, dst any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if dst == nil || resp.StatusCode == http.StatusNoContent {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// send sends the request, retrying it while it fails temporarily if it's
+// idempotent. It returns the response if its status is 2xx, an [*Error]
+// otherwise. The caller must close the body of the response.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("asyncq: encoding the request: %w", err)
+		}
+	}
+
+	retries := 0
+	if req.idempotent && req.rawBody == nil {
+		retries = c.maxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := c.newHTTPRequest(ctx, req, body)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			return resp, nil
+		}
+
+		wait := c.backoff(attempt)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= retries {
+				return nil, err
+			}
+		} else {
+			apiErr := readError(resp)
+			if attempt >= retries || !retryableStatus(resp.StatusCode) {
+				return nil, apiErr
+			}
+			wait = max(wait, retryAfter(resp))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *Client) newHTTPRequest(ctx context.Context, req *request, body []byte) (*http.Request, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var reader io.Reader
+	switch {
+	case req.rawBody != nil:
+		reader = req.rawBody
+	case body != nil:
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range req.header {
+		httpReq.Header[key] = values
+	}
+
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	httpReq.Header.Set("User-Agent", c.userAgent)
+
+	switch {
+	case req.contentType != "":
+		httpReq.Header.Set("Content-Type", req.contentType)
+	case body != nil:
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	switch req.auth {
+	case apiKeyAuth:
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	case tokenAuth:
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return httpReq, nil
+}
+
+// backoff returns how long to wait before retrying a request that failed the
+// given number of times: the retry backoff, doubled on each attempt, with jitter.
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.retryBackoff <= 0 {
+		return 0
+	}
+
+	d := c.retryBackoff << min(attempt, 16)
+	if d <= 0 || d > maxRetryBackoff {
+		d = maxRetryBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryableStatus reports whether a request failing with the status may succeed if retried.
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay asked by the Retry-After header of the response, in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	sec, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || sec < 0 {
+		return 0
+	}
+	return time.Duration(sec) * time.Second
+}
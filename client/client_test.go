@@ -0,0 +1,438 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of an API served by handler, retrying without delay.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{WithAPIKey("test-key"), WithAuthenticationToken("test-token"), WithRetries(3, 0)}, opts...)
+	return New(srv.URL, opts...)
+}
+
+func TestCreateJobRetriesWithSameIdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"job":{"id":"job-1","status":"Queued"}}`)
+	})
+
+	req, err := WebhookJob(&WebhookPayload{URL: "https://example.com/hook"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := c.CreateJob(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+	if j.ID != "job-1" {
+		t.Errorf("job ID = %q, want job-1", j.ID)
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(keys))
+	}
+	for _, key := range keys {
+		if key == "" || key != keys[0] {
+			t.Fatalf("idempotency keys = %q, want the same non-empty key on every attempt", keys)
+		}
+	}
+}
+
+func TestCreateJobSendsGivenIdempotencyKey(t *testing.T) {
+	var got string
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Idempotency-Key")
+		fmt.Fprint(w, `{"job":{"id":"job-1"}}`)
+	})
+
+	req := &CreateJobRequest{Task: WebhookTask, Payload: []byte(`{}`), IdempotencyKey: "order-42"}
+
+	_, err := c.CreateJob(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "order-42" {
+		t.Errorf("Idempotency-Key = %q, want order-42", got)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		maxRetries   int
+		wantAttempts int
+	}{
+		{"server error is retried", http.StatusInternalServerError, 2, 3},
+		{"rate limit is retried", http.StatusTooManyRequests, 2, 3},
+		{"client error isn't retried", http.StatusBadRequest, 2, 1},
+		{"not found isn't retried", http.StatusNotFound, 2, 1},
+		{"retries disabled", http.StatusServiceUnavailable, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}, WithRetries(tt.maxRetries, 0))
+
+			_, err := c.GetJob(context.Background(), "job-1")
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("GetJob() error = %v, want an *Error with status %d", err, tt.status)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestNonIdempotentRequestsAreNotRetried(t *testing.T) {
+	attempts := 0
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	err := c.CancelJob(context.Background(), "job-1")
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("CancelJob() error = %v, want ErrServer", err)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestUploadBlobIsNotRetried(t *testing.T) {
+	attempts := 0
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.UploadBlob(context.Background(), "report.pdf", "application/pdf", strings.NewReader("%PDF"))
+	if err == nil {
+		t.Fatal("UploadBlob() error = nil, want an error")
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var first time.Time
+	var waited time.Duration
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if first.IsZero() {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		waited = time.Since(first)
+		fmt.Fprint(w, `{"status":"Queued"}`)
+	})
+
+	status, err := c.GetJobStatus(context.Background(), "job-1")
+	if err != nil {
+		t.Fatalf("GetJobStatus() error = %v", err)
+	}
+	if status != StatusQueued {
+		t.Errorf("status = %q, want %q", status, StatusQueued)
+	}
+	if waited < time.Second {
+		t.Errorf("retried after %v, want at least the 1s of Retry-After", waited)
+	}
+}
+
+func TestRetryWaitStopsWhenContextIsDone(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetJob(ctx, "job-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetJob() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		want        error
+		wantMessage string
+		wantFields  map[string]string
+	}{
+		{
+			name:        "message",
+			status:      http.StatusNotFound,
+			body:        `{"error":"the requested resource could not be found"}`,
+			want:        ErrNotFound,
+			wantMessage: "the requested resource could not be found",
+		},
+		{
+			name:       "validation fields",
+			status:     http.StatusUnprocessableEntity,
+			body:       `{"error":{"task":"must be provided","payload":"must be provided"}}`,
+			want:       ErrValidation,
+			wantFields: map[string]string{"task": "must be provided", "payload": "must be provided"},
+		},
+		{
+			name:        "message object",
+			status:      http.StatusConflict,
+			body:        `{"error":{"message":"the job isn't running under the given lease"}}`,
+			want:        ErrConflict,
+			wantMessage: "the job isn't running under the given lease",
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"error":"invalid or missing API Key"}`,
+			want:   ErrUnauthorized,
+		},
+		{
+			name:   "not JSON",
+			status: http.StatusBadGateway,
+			body:   `<html>bad gateway</html>`,
+			want:   ErrServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}, WithRetries(0, 0))
+
+			_, err := c.GetJob(context.Background(), "job-1")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetJob() error = %v, want %v", err, tt.want)
+			}
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetJob() error = %T, want *Error", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if tt.wantMessage != "" && apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			for field, want := range tt.wantFields {
+				if apiErr.Fields[field] != want {
+					t.Errorf("Fields[%q] = %q, want %q", field, apiErr.Fields[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	var got []string
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path+" "+r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v1/tokens/authentication":
+			fmt.Fprint(w, `{"token":{"token":"tk_1"}}`)
+		case "/v1/api-keys":
+			fmt.Fprint(w, `{"apiKeys":[]}`)
+		default:
+			fmt.Fprint(w, `{"job":{"id":"job-1"}}`)
+		}
+	})
+
+	ctx := context.Background()
+
+	_, err := c.CreateAuthenticationToken(ctx, &AuthenticationRequest{Email: "a@example.com", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetAPIKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetJob(ctx, "job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"/v1/tokens/authentication ",
+		"/v1/api-keys Bearer test-token",
+		"/v1/jobs/job-1 Bearer test-key",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestAllJobs(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"jobs":[{"id":"job-1"},{"id":"job-2"}],"metadata":{"page_size":2,"next_cursor":"c2"}}`,
+		"c2": `{"jobs":[{"id":"job-3"},{"id":"job-4"}],"metadata":{"page_size":2,"next_cursor":"c3"}}`,
+		"c3": `{"jobs":[{"id":"job-5"}],"metadata":{"page_size":2}}`,
+	}
+
+	var cursors []string
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		qs := r.URL.Query()
+		if !qs.Has("cursor") || qs.Has("page") {
+			t.Errorf("query = %q, want keyset pagination", r.URL.RawQuery)
+		}
+		if qs.Get("task") != "webhook" {
+			t.Errorf("task = %q, want the filters of the params", qs.Get("task"))
+		}
+
+		cursor := qs.Get("cursor")
+		cursors = append(cursors, cursor)
+		fmt.Fprint(w, pages[cursor])
+	})
+
+	params := &SearchJobsParams{Task: WebhookTask, Page: 3}
+
+	var ids []string
+	for j, err := range c.AllJobs(context.Background(), params) {
+		if err != nil {
+			t.Fatalf("AllJobs() error = %v", err)
+		}
+		ids = append(ids, j.ID)
+	}
+
+	if got := strings.Join(ids, ","); got != "job-1,job-2,job-3,job-4,job-5" {
+		t.Errorf("jobs = %s, want job-1 to job-5", got)
+	}
+	if got := strings.Join(cursors, ","); got != ",c2,c3" {
+		t.Errorf("cursors = %q, want the next cursor of each page", got)
+	}
+	if params.Page != 3 || params.Cursor != nil {
+		t.Errorf("params were modified: %+v", params)
+	}
+}
+
+func TestAllJobsStopsWhenLoopBreaks(t *testing.T) {
+	requests := 0
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"jobs":[{"id":"job-1"},{"id":"job-2"}],"metadata":{"next_cursor":"next"}}`)
+	})
+
+	for _, err := range c.AllJobs(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		break
+	}
+
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
+
+func TestAllJobsYieldsError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"jobs":[{"id":"job-1"}],"metadata":{"next_cursor":"next"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"error":{"cursor":"invalid cursor"}}`)
+	})
+
+	var ids []string
+	var gotErr error
+	for j, err := range c.AllJobs(context.Background(), nil) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		ids = append(ids, j.ID)
+	}
+
+	if len(ids) != 1 {
+		t.Errorf("got %d jobs, want the 1 of the first page", len(ids))
+	}
+	if !errors.Is(gotErr, ErrValidation) {
+		t.Errorf("error = %v, want ErrValidation", gotErr)
+	}
+}
+
+func TestJobEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", r.Header.Get("Accept"))
+		}
+		if r.URL.Query().Get("last_event_id") != "7" {
+			t.Errorf("last_event_id = %q, want 7", r.URL.Query().Get("last_event_id"))
+		}
+
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "id: 8\nevent: status\ndata: {\"id\":8,\"job_id\":\"job-1\",\"status\":\"Running\",\"previous_status\":\"Queued\"}\n\n")
+		fmt.Fprint(w, "id: 9\nevent: status\ndata: {\"id\":9,\"job_id\":\"job-1\",\"status\":\"Done\",\"previous_status\":\"Running\"}\n\n")
+	})
+
+	stream, err := c.JobEvents(context.Background(), &JobEventsParams{LastEventID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	for _, want := range []Status{StatusRunning, StatusDone} {
+		e, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if e.JobID != "job-1" || e.Status != want {
+			t.Errorf("event = %+v, want job-1 %s", e, want)
+		}
+	}
+
+	_, err = stream.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Next() error = %v, want io.EOF at the end of the stream", err)
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) CreateEmailTemplate(ctx context.Context, req *EmailTemplateRequest) (*EmailTemplate, error) {
+	var resp struct {
+		EmailTemplate *EmailTemplate `json:"emailTemplate"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/email-templates",
+		auth:   apiKeyAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.EmailTemplate, nil
+}
+
+func (c *Client) GetEmailTemplates(ctx context.Context) ([]*EmailTemplate, error) {
+	var resp struct {
+		EmailTemplates []*EmailTemplate `json:"emailTemplates"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/email-templates",
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.EmailTemplates, nil
+}
+
+func (c *Client) GetEmailTemplate(ctx context.Context, id string) (*EmailTemplate, error) {
+	var resp struct {
+		EmailTemplate *EmailTemplate `json:"emailTemplate"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/email-templates/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.EmailTemplate, nil
+}
+
+func (c *Client) UpdateEmailTemplate(ctx context.Context, id string, req *EmailTemplateRequest) (*EmailTemplate, error) {
+	var resp struct {
+		EmailTemplate *EmailTemplate `json:"emailTemplate"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPut,
+		path:       "/v1/email-templates/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		body:       req,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.EmailTemplate, nil
+}
+
+func (c *Client) DeleteEmailTemplate(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/email-templates/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, nil)
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Errors matched by the [*Error] of the response status, e.g.
+//
+//	if errors.Is(err, client.ErrNotFound) {
+var (
+	ErrBadRequest   = errors.New("asyncq: bad request")
+	ErrUnauthorized = errors.New("asyncq: unauthorized")
+	ErrForbidden    = errors.New("asyncq: forbidden")
+	ErrNotFound     = errors.New("asyncq: not found")
+	ErrConflict     = errors.New("asyncq: conflict")
+	ErrTooLarge     = errors.New("asyncq: content too large")
+	ErrValidation   = errors.New("asyncq: failed validation")
+	ErrRateLimited  = errors.New("asyncq: rate limited")
+	ErrServer       = errors.New("asyncq: server error")
+)
+
+// Error is an error response of the API.
+type Error struct {
+	StatusCode int
+	// Describes the error, unless it's about the fields of the request
+	Message string
+	// The errors of the fields of the request, keyed by their name, e.g. of a
+	// failed validation
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("asyncq: %d %s", e.StatusCode, e.Message)
+	}
+
+	fields := make([]string, 0, len(e.Fields))
+	for _, name := range slices.Sorted(maps.Keys(e.Fields)) {
+		fields = append(fields, name+": "+e.Fields[name])
+	}
+	return fmt.Sprintf("asyncq: %d %s", e.StatusCode, strings.Join(fields, ", "))
+}
+
+// Unwrap returns the sentinel error of the status, nil if there's none.
+func (e *Error) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusRequestEntityTooLarge:
+		return ErrTooLarge
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// readError reads the error of the response and closes its body. The API
+// responds with {"error": <message>}, where message is a string or, for
+// errors about the fields of the request, an object.
+func readError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return apiErr
+	}
+
+	var env struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &env) != nil || env.Error == nil {
+		return apiErr
+	}
+
+	var message string
+	if json.Unmarshal(env.Error, &message) == nil {
+		apiErr.Message = message
+		return apiErr
+	}
+
+	var fields map[string]string
+	if json.Unmarshal(env.Error, &fields) == nil {
+		// conflicts are an object with just a message
+		if message, ok := fields["message"]; ok && len(fields) == 1 {
+			apiErr.Message = message
+			return apiErr
+		}
+		apiErr.Message = ""
+		apiErr.Fields = fields
+	}
+
+	return apiErr
+}
@@ -0,0 +1,394 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewJob returns the request creating a job of the task, with the payload encoded as JSON.
+func NewJob(t Task, payload any) (*CreateJobRequest, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("asyncq: encoding the payload: %w", err)
+	}
+
+	return &CreateJobRequest{Task: t, Payload: data}, nil
+}
+
+// WebhookJob returns the request creating a job calling the webhook.
+func WebhookJob(payload *WebhookPayload) (*CreateJobRequest, error) {
+	return NewJob(WebhookTask, payload)
+}
+
+// SendEmailJob returns the request creating a job sending the email.
+func SendEmailJob(payload *SendEmailPayload) (*CreateJobRequest, error) {
+	return NewJob(SendEmailTask, payload)
+}
+
+// ExternalJob returns the request creating a job leased by the consumers of the
+// named task, see [Client.LeaseJobs], with data as the input they get.
+func ExternalJob(name string, data any) (*CreateJobRequest, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("asyncq: encoding the data: %w", err)
+	}
+
+	return NewJob(ExternalTask, &ExternalPayload{Name: name, Data: encoded})
+}
+
+// CreateJob creates the job. It's sent with the idempotency key of the request,
+// or a random one if it has none, so retrying it returns the job created by the
+// first attempt. Use the same key to safely retry the creation across calls.
+func (c *Client) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, error) {
+	key := req.IdempotencyKey
+	if key == "" {
+		key = uuid.NewString()
+	}
+
+	var resp struct {
+		Job *Job `json:"job"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPost,
+		path:       "/v1/jobs",
+		auth:       apiKeyAuth,
+		body:       req,
+		header:     http.Header{"Idempotency-Key": {key}},
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var resp struct {
+		Job *Job `json:"job"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/jobs/" + url.PathEscape(id),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+func (c *Client) GetJobStatus(ctx context.Context, id string) (Status, error) {
+	var resp struct {
+		Status Status `json:"status"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/jobs/" + url.PathEscape(id) + "/status",
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Status, nil
+}
+
+// ScheduleJob queues the job to run at runAt, e.g. a failed one to be run again.
+func (c *Client) ScheduleJob(ctx context.Context, id string, runAt time.Time) error {
+	return c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/jobs/" + url.PathEscape(id) + "/schedule",
+		auth:   apiKeyAuth,
+		body: struct {
+			RunAt time.Time `json:"run_at"`
+		}{runAt},
+	}, nil)
+}
+
+func (c *Client) CancelJob(ctx context.Context, id string) error {
+	return c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/jobs/" + url.PathEscape(id) + "/cancel",
+		auth:   apiKeyAuth,
+	}, nil)
+}
+
+// SearchJobsParams filters the jobs searched, fields not set don't filter them.
+type SearchJobsParams struct {
+	Task Task
+	// Jobs must have one of the statuses
+	Statuses        []Status
+	RunBefore       *time.Time
+	RunAfter        *time.Time
+	CreatedBefore   *time.Time
+	CreatedAfter    *time.Time
+	FinishedBefore  *time.Time
+	FinishedAfter   *time.Time
+	HeartbeatBefore *time.Time
+	RetriesGTE      *int
+	HasError        *bool
+	// Text searched in the last error and in the payload
+	Query string
+	// Jobs must have all the tags
+	Tags []string
+	// Jobs must have all the key/value pairs
+	Metadata map[string]string
+
+	// The API defaults are used when not set
+	Page     int
+	PageSize int
+	// e.g. -created_at, the API default
+	SortBy string
+	// When set, keyset pagination is used instead of Page. An empty cursor
+	// requests the first page, the next ones the NextCursor of the previous.
+	Cursor *string
+}
+
+func (p *SearchJobsParams) values() url.Values {
+	qs := url.Values{}
+	if p == nil {
+		return qs
+	}
+
+	setTime := func(key string, t *time.Time) {
+		if t != nil {
+			qs.Set(key, t.Format(time.RFC3339))
+		}
+	}
+
+	if p.Task != "" {
+		qs.Set("task", string(p.Task))
+	}
+	if len(p.Statuses) > 0 {
+		statuses := make([]string, len(p.Statuses))
+		for i, status := range p.Statuses {
+			statuses[i] = string(status)
+		}
+		qs.Set("status", strings.Join(statuses, ","))
+	}
+	setTime("run_before", p.RunBefore)
+	setTime("run_after", p.RunAfter)
+	setTime("created_before", p.CreatedBefore)
+	setTime("created_after", p.CreatedAfter)
+	setTime("finished_before", p.FinishedBefore)
+	setTime("finished_after", p.FinishedAfter)
+	setTime("heartbeat_before", p.HeartbeatBefore)
+	if p.RetriesGTE != nil {
+		qs.Set("retries_gte", strconv.Itoa(*p.RetriesGTE))
+	}
+	if p.HasError != nil {
+		qs.Set("has_error", strconv.FormatBool(*p.HasError))
+	}
+	if p.Query != "" {
+		qs.Set("q", p.Query)
+	}
+	for _, tag := range p.Tags {
+		qs.Add("tag", tag)
+	}
+	for key, value := range p.Metadata {
+		qs.Set("metadata."+key, value)
+	}
+	if p.Page > 0 {
+		qs.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		qs.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+	if p.SortBy != "" {
+		qs.Set("sort_by", p.SortBy)
+	}
+	if p.Cursor != nil {
+		qs.Set("cursor", *p.Cursor)
+	}
+
+	return qs
+}
+
+// SearchJobs returns a page of the jobs matching the params.
+func (c *Client) SearchJobs(ctx context.Context, params *SearchJobsParams) ([]*Job, *PaginationMetadata, error) {
+	var resp struct {
+		Jobs     []*Job              `json:"jobs"`
+		Metadata *PaginationMetadata `json:"metadata"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/jobs",
+		query:      params.values(),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Jobs, resp.Metadata, nil
+}
+
+// AllJobs iterates over every job matching the params, requesting the pages as
+// needed with keyset pagination, so jobs created meanwhile don't shift them.
+// The Page and Cursor of params are ignored. Iteration stops after the first
+// error, which is yielded with a nil job:
+//
+//	for j, err := range c.AllJobs(ctx, &client.SearchJobsParams{Task: client.WebhookTask}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) AllJobs(ctx context.Context, params *SearchJobsParams) iter.Seq2[*Job, error] {
+	return func(yield func(*Job, error) bool) {
+		p := SearchJobsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Page = 0
+		p.Cursor = new(string)
+
+		for {
+			jobs, metadata, err := c.SearchJobs(ctx, &p)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, j := range jobs {
+				if !yield(j, nil) {
+					return
+				}
+			}
+
+			if metadata == nil || metadata.NextCursor == "" {
+				return
+			}
+			p.Cursor = &metadata.NextCursor
+		}
+	}
+}
+
+// ExportJobs returns every job matching the params, encoded in the format,
+// ndjson or csv. The pagination params are ignored. The caller must close
+// the returned reader.
+func (c *Client) ExportJobs(ctx context.Context, params *SearchJobsParams, format string) (io.ReadCloser, error) {
+	qs := params.values()
+	qs.Set("format", format)
+
+	resp, err := c.send(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/jobs/export",
+		query:      qs,
+		auth:       apiKeyAuth,
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// JobEventsParams filters the events streamed, fields not set don't filter them.
+type JobEventsParams struct {
+	Task   Task
+	Status Status
+	JobID  string
+	// Resumes a stream, sending first the events stored after this one
+	LastEventID int64
+}
+
+// JobEvents streams the status changes of the account's jobs. The stream lasts
+// until it's closed or ctx is done.
+func (c *Client) JobEvents(ctx context.Context, params *JobEventsParams) (*JobEventStream, error) {
+	qs := url.Values{}
+	if params != nil {
+		if params.Task != "" {
+			qs.Set("task", string(params.Task))
+		}
+		if params.Status != "" {
+			qs.Set("status", string(params.Status))
+		}
+		if params.JobID != "" {
+			qs.Set("job_id", params.JobID)
+		}
+		if params.LastEventID > 0 {
+			qs.Set("last_event_id", strconv.FormatInt(params.LastEventID, 10))
+		}
+	}
+
+	resp, err := c.send(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/jobs/events",
+		query:      qs,
+		auth:       apiKeyAuth,
+		header:     http.Header{"Accept": {"text/event-stream"}},
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobEventStream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// JobEventStream reads the Server-Sent Events of [Client.JobEvents].
+type JobEventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Next waits for the next event. It returns [io.EOF] when the API ends the stream.
+func (s *JobEventStream) Next() (*JobEvent, error) {
+	var data []byte
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		// a blank line ends an event, comments keep the stream alive
+		if line == "" {
+			if data == nil {
+				continue
+			}
+
+			var e JobEvent
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return nil, fmt.Errorf("asyncq: decoding the event: %w", err)
+			}
+			return &e, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		if field == "data" {
+			data = append(data, strings.TrimPrefix(value, " ")...)
+		}
+	}
+
+	err := s.scanner.Err()
+	if err == nil || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, io.EOF
+	}
+	return nil, err
+}
+
+func (s *JobEventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// LeaseJobs hands the due external jobs of the tasks to the caller, which must
+// complete or fail each of them before the lease expires, sending heartbeats to
+// extend it if needed. The lease has no jobs when there are none due.
+func (c *Client) LeaseJobs(ctx context.Context, req *LeaseRequest) (*Lease, error) {
+	var resp struct {
+		Lease *Lease `json:"lease"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/workers/lease",
+		auth:   apiKeyAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Lease, nil
+}
+
+// HeartbeatJob extends the lease of the job and returns when it expires. It
+// fails with [ErrConflict] when the job isn't leased anymore, e.g. because the
+// lease expired and the job was retried.
+func (c *Client) HeartbeatJob(ctx context.Context, id string, req *LeaseHeartbeatRequest) (time.Time, error) {
+	var resp struct {
+		LeaseExpiresAt time.Time `json:"lease_expires_at"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodPost,
+		path:       "/v1/jobs/" + url.PathEscape(id) + "/heartbeat",
+		auth:       apiKeyAuth,
+		body:       req,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return resp.LeaseExpiresAt, nil
+}
+
+// CompleteJob finishes the leased job as done.
+func (c *Client) CompleteJob(ctx context.Context, id string, req *LeaseCompleteRequest) error {
+	return c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/jobs/" + url.PathEscape(id) + "/complete",
+		auth:   apiKeyAuth,
+		body:   req,
+	}, nil)
+}
+
+// FailJob fails the attempt of the leased job, which is retried while it has
+// retries left unless the failure is permanent.
+func (c *Client) FailJob(ctx context.Context, id string, req *LeaseFailRequest) error {
+	return c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/jobs/" + url.PathEscape(id) + "/fail",
+		auth:   apiKeyAuth,
+		body:   req,
+	}, nil)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CreateSuppression stops the emails to the address from being sent.
+func (c *Client) CreateSuppression(ctx context.Context, req *SuppressionRequest) (*Suppression, error) {
+	var resp struct {
+		Suppression *Suppression `json:"suppression"`
+	}
+
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/v1/email-suppressions",
+		auth:   apiKeyAuth,
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Suppression, nil
+}
+
+func (c *Client) GetSuppressions(ctx context.Context) ([]*Suppression, error) {
+	var resp struct {
+		Suppressions []*Suppression `json:"suppressions"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/email-suppressions",
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Suppressions, nil
+}
+
+func (c *Client) GetSuppression(ctx context.Context, address string) (*Suppression, error) {
+	var resp struct {
+		Suppression *Suppression `json:"suppression"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/email-suppressions/" + url.PathEscape(address),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Suppression, nil
+}
+
+// DeleteSuppression allows the emails to the address to be sent again.
+func (c *Client) DeleteSuppression(ctx context.Context, address string) error {
+	return c.do(ctx, &request{
+		method:     http.MethodDelete,
+		path:       "/v1/email-suppressions/" + url.PathEscape(address),
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, nil)
+}
+
+// IngestEmailNotification suppresses the addresses of a bounce or complaint
+// notification of the email provider, forwarded as is with its content type,
+// and returns the suppressions created.
+func (c *Client) IngestEmailNotification(ctx context.Context, contentType string, notification []byte) ([]*Suppression, error) {
+	var resp struct {
+		Suppressions []*Suppression `json:"suppressions"`
+	}
+
+	err := c.do(ctx, &request{
+		method:      http.MethodPost,
+		path:        "/v1/email-notifications",
+		auth:        apiKeyAuth,
+		rawBody:     bytes.NewReader(notification),
+		contentType: contentType,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Suppressions, nil
+}
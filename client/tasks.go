@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Health is the status of the API.
+type Health struct {
+	Status      string `json:"status"`
+	Environment string `json:"environment"`
+}
+
+// Health reports whether the API is available. It needs no credentials.
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	var resp Health
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/health",
+		auth:       noAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetTasks returns the tasks jobs can be created for, with the JSON schemas of their payloads.
+func (c *Client) GetTasks(ctx context.Context) ([]*TaskInfo, error) {
+	var resp struct {
+		Tasks []*TaskInfo `json:"tasks"`
+	}
+
+	err := c.do(ctx, &request{
+		method:     http.MethodGet,
+		path:       "/v1/tasks",
+		auth:       apiKeyAuth,
+		idempotent: true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tasks, nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"github.com/ngmmartins/asyncq/internal/account"
+	"github.com/ngmmartins/asyncq/internal/apikey"
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/emailtemplate"
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/pagination"
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/sender"
+	"github.com/ngmmartins/asyncq/internal/signing"
+	"github.com/ngmmartins/asyncq/internal/suppression"
+	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/token"
+	"github.com/ngmmartins/asyncq/registry"
+)
+
+// The resources of the API and the requests creating or changing them.
+type (
+	Job              = job.Job
+	Status           = job.Status
+	Progress         = job.Progress
+	CreateJobRequest = job.CreateRequest
+	// Metadata of a page of search results
+	PaginationMetadata = pagination.Metadata
+	JobEvent           = event.Event
+
+	Task             = task.Task
+	TaskInfo         = registry.Task
+	WebhookPayload   = task.WebhookPayload
+	WebhookAuth      = task.WebhookAuth
+	WebhookAuthType  = task.WebhookAuthType
+	SendEmailPayload = task.SendEmailPayload
+	EmailAttachment  = task.EmailAttachment
+	ExternalPayload  = task.ExternalPayload
+
+	Lease                 = job.Lease
+	LeaseRequest          = job.LeaseRequest
+	LeaseHeartbeatRequest = job.LeaseHeartbeatRequest
+	ProgressRequest       = job.ProgressRequest
+	LeaseCompleteRequest  = job.LeaseCompleteRequest
+	LeaseFailRequest      = job.LeaseFailRequest
+
+	AuthenticationRequest      = token.AuthenticationRequest
+	AuthenticationToken        = token.Token
+	APIKey                     = apikey.APIKey
+	CreateAPIKeyRequest        = apikey.CreateRequest
+	UpdateJobRetentionRequest  = account.UpdateJobRetentionRequest
+	SigningSecret              = signing.Secret
+	RotateSigningSecretRequest = signing.RotateRequest
+	Secret                     = secret.Secret
+	PutSecretRequest           = secret.PutRequest
+	SMTPConfig                 = sender.SMTPConfig
+	SMTPConfigRequest          = sender.SMTPConfigRequest
+	SenderIdentity             = sender.Identity
+	SenderIdentityRequest      = sender.IdentityRequest
+
+	EmailTemplate        = emailtemplate.Template
+	EmailTemplateRequest = emailtemplate.Request
+	Blob                 = blob.Blob
+	Suppression          = suppression.Suppression
+	SuppressionRequest   = suppression.Request
+	SuppressionReason    = suppression.Reason
+)
+
+const (
+	StatusCreated    = job.StatusCreated
+	StatusQueued     = job.StatusQueued
+	StatusRunning    = job.StatusRunning
+	StatusDone       = job.StatusDone
+	StatusFailed     = job.StatusFailed
+	StatusCancelled  = job.StatusCancelled
+	StatusExpired    = job.StatusExpired
+	StatusSuppressed = job.StatusSuppressed
+)
+
+const (
+	WebhookTask   = task.WebhookTask
+	SendEmailTask = task.SendEmailTask
+	ExternalTask  = task.ExternalTask
+)
+
+const (
+	WebhookAuthBasic                   = task.WebhookAuthBasic
+	WebhookAuthBearer                  = task.WebhookAuthBearer
+	WebhookAuthOAuth2ClientCredentials = task.WebhookAuthOAuth2ClientCredentials
+)
+
+const (
+	SuppressionReasonBounce      = suppression.ReasonBounce
+	SuppressionReasonComplaint   = suppression.ReasonComplaint
+	SuppressionReasonUnsubscribe = suppression.ReasonUnsubscribe
+	SuppressionReasonManual      = suppression.ReasonManual
+)
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/client"
+)
+
+// loginCommand prints an authentication token of the account's user. The
+// password is read from ASYNCQ_PASSWORD or, if not set, from the first line of
+// the standard input.
+func loginCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	email := fs.String("email", os.Getenv("ASYNCQ_EMAIL"), "Email of the account's user (env ASYNCQ_EMAIL)")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		return errors.New("login: -email is required")
+	}
+
+	password := os.Getenv("ASYNCQ_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("login: reading the password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	t, err := c.client.CreateAuthenticationToken(ctx, &client.AuthenticationRequest{Email: *email, Password: password})
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(t, func() [][]string {
+		return [][]string{
+			{"TOKEN", "EXPIRES AT"},
+			{t.Plaintext, formatTime(&t.ExpiresAt)},
+		}
+	})
+}
+
+func apiKeysCommand(ctx context.Context, c *cli, args []string) error {
+	return subcommand(ctx, c, "api-keys", args, map[string]command{
+		"create": createAPIKeyCommand,
+		"list":   listAPIKeysCommand,
+		"get":    getAPIKeyCommand,
+		"delete": deleteAPIKeyCommand,
+	})
+}
+
+func createAPIKeyCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("api-keys create", flag.ContinueOnError)
+	name := fs.String("name", "", "Name of the API key")
+	expiresIn := fs.Duration("expires-in", 0, "How long until the API key expires, e.g. 720h (never if not set)")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	req := &client.CreateAPIKeyRequest{Name: *name}
+	if *expiresIn > 0 {
+		expiresAt := time.Now().Add(*expiresIn)
+		req.ExpiresAt = &expiresAt
+	}
+
+	key, err := c.client.CreateAPIKey(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(key, func() [][]string {
+		return [][]string{
+			{"ID", "NAME", "KEY", "EXPIRES AT"},
+			{key.ID, key.Name, key.Key, formatTime(key.ExpiresAt)},
+		}
+	})
+}
+
+func listAPIKeysCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("api-keys list", flag.ContinueOnError)
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	keys, err := c.client.GetAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(keys, func() [][]string {
+		rows := [][]string{{"ID", "NAME", "CREATED AT", "EXPIRES AT"}}
+		for _, key := range keys {
+			rows = append(rows, []string{key.ID, key.Name, formatTime(&key.CreatedAt), formatTime(key.ExpiresAt)})
+		}
+		return rows
+	})
+}
+
+func getAPIKeyCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("api-keys get", flag.ContinueOnError)
+	args, err := parseFlags(fs, args, 1, "<id>")
+	if err != nil {
+		return err
+	}
+
+	key, err := c.client.GetAPIKey(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(key, func() [][]string {
+		return [][]string{
+			{"ID", "NAME", "CREATED AT", "EXPIRES AT"},
+			{key.ID, key.Name, formatTime(&key.CreatedAt), formatTime(key.ExpiresAt)},
+		}
+	})
+}
+
+func deleteAPIKeyCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("api-keys delete", flag.ContinueOnError)
+	args, err := parseFlags(fs, args, 1, "<id>")
+	if err != nil {
+		return err
+	}
+
+	return c.client.DeleteAPIKey(ctx, args[0])
+}
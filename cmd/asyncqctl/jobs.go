@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/client"
+)
+
+// the statuses queue counts the jobs of, in the order they're printed
+var statuses = []client.Status{
+	client.StatusCreated, client.StatusQueued, client.StatusRunning, client.StatusDone,
+	client.StatusFailed, client.StatusCancelled, client.StatusExpired, client.StatusSuppressed,
+}
+
+func jobsCommand(ctx context.Context, c *cli, args []string) error {
+	return subcommand(ctx, c, "jobs", args, map[string]command{
+		"create": createJobCommand,
+		"get":    getJobCommand,
+		"search": searchJobsCommand,
+		"cancel": cancelJobCommand,
+		"retry":  retryJobCommand,
+		"events": jobEventsCommand,
+	})
+}
+
+func createJobCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs create", flag.ContinueOnError)
+	t := fs.String("task", "", "Task of the job, e.g. webhook (see GET /v1/tasks)")
+	payload := fs.String("payload", "", "JSON payload of the job, @file to read it from a file or - from the standard input")
+	runAt := timeFlag(fs, "run-at", "When to run the job, RFC 3339 (now if not set)")
+	expiresAt := timeFlag(fs, "expires-at", "When the job expires if it didn't run yet, RFC 3339")
+	maxRetries := intFlag(fs, "max-retries", "Maximum number of retries (API default if not set)")
+	retryDelay := intFlag(fs, "retry-delay-sec", "Seconds between each retry (API default if not set)")
+	var tags stringsFlag
+	fs.Var(&tags, "tag", "Tag of the job, can be repeated")
+	metadata := metadataFlag{}
+	fs.Var(metadata, "metadata", "key=value metadata of the job, can be repeated")
+	idempotencyKey := fs.String("idempotency-key", "", "Key that makes creating the job again with it return the same job (random if not set)")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	data, err := readPayload(*payload)
+	if err != nil {
+		return err
+	}
+
+	req := &client.CreateJobRequest{
+		Task:           client.Task(*t),
+		Payload:        data,
+		RunAt:          *runAt,
+		MaxRetries:     *maxRetries,
+		RetryDelaySec:  *retryDelay,
+		Tags:           tags,
+		Metadata:       metadata,
+		ExpiresAt:      *expiresAt,
+		IdempotencyKey: *idempotencyKey,
+	}
+
+	j, err := c.client.CreateJob(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.printJob(j)
+}
+
+func getJobCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs get", flag.ContinueOnError)
+	args, err := parseFlags(fs, args, 1, "<id>")
+	if err != nil {
+		return err
+	}
+
+	j, err := c.client.GetJob(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.printJob(j)
+}
+
+func searchJobsCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs search", flag.ContinueOnError)
+	t := fs.String("task", "", "Task of the jobs")
+	status := fs.String("status", "", "Comma separated statuses the jobs must have one of")
+	var tags stringsFlag
+	fs.Var(&tags, "tag", "Tag the jobs must have, can be repeated")
+	metadata := metadataFlag{}
+	fs.Var(metadata, "metadata", "key=value metadata the jobs must have, can be repeated")
+	q := fs.String("q", "", "Text searched in the last error and in the payload")
+	createdAfter := timeFlag(fs, "created-after", "Jobs created after, RFC 3339")
+	createdBefore := timeFlag(fs, "created-before", "Jobs created before, RFC 3339")
+	page := fs.Int("page", 0, "Page (API default if not set)")
+	pageSize := fs.Int("page-size", 0, "Jobs per page (API default if not set)")
+	sortBy := fs.String("sort", "", "Sort field, prefixed by - for descending order, e.g. -created_at")
+	all := fs.Bool("all", false, "Print every matching job instead of a page")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	params := &client.SearchJobsParams{
+		Task:          client.Task(*t),
+		Tags:          tags,
+		Metadata:      metadata,
+		Query:         *q,
+		CreatedAfter:  *createdAfter,
+		CreatedBefore: *createdBefore,
+		Page:          *page,
+		PageSize:      *pageSize,
+		SortBy:        *sortBy,
+	}
+	if *status != "" {
+		for s := range strings.SplitSeq(*status, ",") {
+			params.Statuses = append(params.Statuses, client.Status(strings.TrimSpace(s)))
+		}
+	}
+
+	var jobs []*client.Job
+	var metadataResp *client.PaginationMetadata
+	if *all {
+		for j, err := range c.client.AllJobs(ctx, params) {
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, j)
+		}
+	} else {
+		var err error
+		jobs, metadataResp, err = c.client.SearchJobs(ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	var v any = jobs
+	if metadataResp != nil {
+		v = map[string]any{"jobs": jobs, "metadata": metadataResp}
+	}
+
+	return c.out.print(v, func() [][]string {
+		rows := [][]string{{"ID", "TASK", "STATUS", "RUN AT", "CREATED AT", "RETRIES", "LAST ERROR"}}
+		for _, j := range jobs {
+			rows = append(rows, []string{
+				j.ID, string(j.Task), string(j.Status), formatTime(j.RunAt), formatTime(&j.CreatedAt),
+				fmt.Sprintf("%d/%d", j.Retries, j.MaxRetries), formatString(j.LastError),
+			})
+		}
+		if metadataResp != nil && metadataResp.TotalRecords > 0 {
+			rows = append(rows, []string{}, []string{fmt.Sprintf("page %d of %d, %d jobs",
+				metadataResp.CurrentPage, metadataResp.LastPage, metadataResp.TotalRecords)})
+		}
+		return rows
+	})
+}
+
+func cancelJobCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs cancel", flag.ContinueOnError)
+	args, err := parseFlags(fs, args, 1, "<id>")
+	if err != nil {
+		return err
+	}
+
+	return c.client.CancelJob(ctx, args[0])
+}
+
+// retryJobCommand queues a job again, e.g. a failed one, to run now or at the given time.
+func retryJobCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs retry", flag.ContinueOnError)
+	at := timeFlag(fs, "at", "When to run the job, RFC 3339 (now if not set)")
+	args, err := parseFlags(fs, args, 1, "<id>")
+	if err != nil {
+		return err
+	}
+
+	runAt := time.Now()
+	if *at != nil {
+		runAt = **at
+	}
+
+	return c.client.ScheduleJob(ctx, args[0], runAt)
+}
+
+// jobEventsCommand prints the status changes of the jobs as they happen, until interrupted.
+func jobEventsCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("jobs events", flag.ContinueOnError)
+	t := fs.String("task", "", "Task of the jobs")
+	status := fs.String("status", "", "Status the jobs changed to")
+	jobID := fs.String("job", "", "ID of the job")
+	lastEventID := fs.Int64("last-event-id", 0, "Print first the events stored after this one")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	stream, err := c.client.JobEvents(ctx, &client.JobEventsParams{
+		Task:        client.Task(*t),
+		Status:      client.Status(*status),
+		JobID:       *jobID,
+		LastEventID: *lastEventID,
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		e, err := stream.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		previous := string(e.PreviousStatus)
+		if previous == "" {
+			previous = "-"
+		}
+
+		err = c.out.printLine(e, []string{
+			formatTime(&e.CreatedAt), e.JobID, string(e.Task), previous + " -> " + string(e.Status),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// queueCommand prints how many of the account's jobs there are in each
+// status, the queued ones being those waiting to run.
+func queueCommand(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("queue", flag.ContinueOnError)
+	t := fs.String("task", "", "Count only the jobs of the task")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	counts := make(map[client.Status]int, len(statuses))
+	for _, status := range statuses {
+		_, metadata, err := c.client.SearchJobs(ctx, &client.SearchJobsParams{
+			Task:     client.Task(*t),
+			Statuses: []client.Status{status},
+			PageSize: 1,
+		})
+		if err != nil {
+			return err
+		}
+		counts[status] = metadata.TotalRecords
+	}
+
+	return c.out.print(counts, func() [][]string {
+		rows := [][]string{{"STATUS", "JOBS"}}
+		for _, status := range statuses {
+			rows = append(rows, []string{string(status), strconv.Itoa(counts[status])})
+		}
+		return rows
+	})
+}
+
+func (c *cli) printJob(j *client.Job) error {
+	return c.out.print(j, func() [][]string {
+		rows := [][]string{
+			{"ID", j.ID},
+			{"TASK", string(j.Task)},
+			{"STATUS", string(j.Status)},
+			{"PAYLOAD", string(j.Payload)},
+			{"RUN AT", formatTime(j.RunAt)},
+			{"CREATED AT", formatTime(&j.CreatedAt)},
+			{"FINISHED AT", formatTime(j.FinishedAt)},
+			{"EXPIRES AT", formatTime(j.ExpiresAt)},
+			{"RETRIES", fmt.Sprintf("%d/%d every %ds", j.Retries, j.MaxRetries, j.RetryDelaySec)},
+			{"LAST ERROR", formatString(j.LastError)},
+			{"TAGS", strings.Join(j.Tags, ", ")},
+		}
+		for key, value := range j.Metadata {
+			rows = append(rows, []string{"METADATA", key + "=" + value})
+		}
+		if j.Progress != nil {
+			rows = append(rows, []string{"PROGRESS", fmt.Sprintf("%d%% %s", j.Progress.Percent, j.Progress.Message)})
+		}
+		return rows
+	})
+}
+
+// readPayload returns the JSON payload given as is, or read from @file or from the standard input for -.
+func readPayload(payload string) (json.RawMessage, error) {
+	var data []byte
+	var err error
+
+	switch {
+	case payload == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case strings.HasPrefix(payload, "@"):
+		data, err = os.ReadFile(payload[1:])
+	default:
+		data = []byte(payload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading the payload: %w", err)
+	}
+
+	if len(data) > 0 && !json.Valid(data) {
+		return nil, errors.New("the payload must be valid JSON")
+	}
+
+	return data, nil
+}
+
+// stringsFlag is a flag that can be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// metadataFlag is a key=value flag that can be repeated.
+type metadataFlag map[string]string
+
+func (f metadataFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for key, value := range f {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f metadataFlag) Set(value string) error {
+	key, value, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return errors.New("must be key=value")
+	}
+	f[key] = value
+	return nil
+}
+
+// timeFlag defines an RFC 3339 time flag, nil if not set.
+func timeFlag(fs *flag.FlagSet, name, usage string) **time.Time {
+	var t *time.Time
+	fs.Func(name, usage, func(value string) error {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.New("must be an RFC 3339 time, e.g. 2025-01-02T15:04:05Z")
+		}
+		t = &parsed
+		return nil
+	})
+	return &t
+}
+
+// intFlag defines an int flag, nil if not set.
+func intFlag(fs *flag.FlagSet, name, usage string) **int {
+	var n *int
+	fs.Func(name, usage, func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		n = &parsed
+		return nil
+	})
+	return &n
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ngmmartins/asyncq/client"
+)
+
+const usage = `asyncqctl manages the jobs and API keys of an asyncq account.
+
+Usage:
+  asyncqctl [flags] <command> [arguments]
+
+Commands:
+  login                  create an authentication token, needed by the api-keys commands
+  api-keys create|list|get|delete
+                         manage the API keys of the account
+  jobs create|get|search|cancel|retry|events
+                         manage the jobs of the account
+  queue                  show how many jobs there are in each status
+
+Run 'asyncqctl <command> -h' for the flags of a command.
+
+Flags:
+`
+
+type config struct {
+	url    string
+	apiKey string
+	token  string
+	output string
+}
+
+// cli is what the commands share: the API client and the format they print in.
+type cli struct {
+	client *client.Client
+	out    *printer
+}
+
+type command func(ctx context.Context, c *cli, args []string) error
+
+var commands = map[string]command{
+	"login":    loginCommand,
+	"api-keys": apiKeysCommand,
+	"jobs":     jobsCommand,
+	"queue":    queueCommand,
+}
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.url, "url", envOr("ASYNCQ_URL", "http://localhost:4040"), "API base URL (env ASYNCQ_URL)")
+	flag.StringVar(&cfg.apiKey, "api-key", os.Getenv("ASYNCQ_API_KEY"), "API key the jobs are accessed with (env ASYNCQ_API_KEY)")
+	flag.StringVar(&cfg.token, "token", os.Getenv("ASYNCQ_TOKEN"), "Authentication token the API keys are accessed with, see login (env ASYNCQ_TOKEN)")
+	flag.StringVar(&cfg.output, "o", "table", "Output format (table|json)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if cfg.output != "table" && cfg.output != "json" {
+		fatal(fmt.Errorf("invalid output format %q, must be table or json", cfg.output))
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c := &cli{
+		client: client.New(cfg.url,
+			client.WithAPIKey(cfg.apiKey),
+			client.WithAuthenticationToken(cfg.token),
+			client.WithUserAgent("asyncqctl"),
+		),
+		out: &printer{w: os.Stdout, json: cfg.output == "json"},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := cmd(ctx, c, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		// interrupting a command, e.g. jobs events, is how it's meant to be stopped
+		if ctx.Err() != nil {
+			return
+		}
+		stop()
+		fatal(err)
+	}
+}
+
+// subcommand runs the subcommand named by the first argument.
+func subcommand(ctx context.Context, c *cli, name string, args []string, subcommands map[string]command) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s: missing subcommand", name)
+	}
+
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return fmt.Errorf("%s: unknown subcommand %q", name, args[0])
+	}
+
+	return cmd(ctx, c, args[1:])
+}
+
+// parseFlags parses the flags of a command, which must be given before its
+// arguments, and returns the arguments, failing if there aren't n of them.
+func parseFlags(fs *flag.FlagSet, args []string, n int, argsUsage string) ([]string, error) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: asyncqctl %s [flags] %s\n", fs.Name(), argsUsage)
+		fs.PrintDefaults()
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() != n {
+		fs.Usage()
+		return nil, flag.ErrHelp
+	}
+
+	return fs.Args(), nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
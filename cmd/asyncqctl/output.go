@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// printer writes the results of the commands, as JSON or as a table for people.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// print writes v as JSON, or the rows of its table, the first one being the header.
+func (p *printer) print(v any, rows func() [][]string) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, row := range rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printLine writes v as a single line of JSON, so a stream of them can be
+// piped, or the row of its table without a header.
+func (p *printer) printLine(v any, row []string) error {
+	if p.json {
+		return json.NewEncoder(p.w).Encode(v)
+	}
+
+	_, err := fmt.Fprintln(p.w, strings.Join(row, "  "))
+	return err
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func formatString(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+	return *s
+}
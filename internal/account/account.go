@@ -14,6 +14,15 @@ type Account struct {
 	Password  password  `json:"-"`
 	Activated bool      `json:"activted"`
 	CreatedAt time.Time `json:"created_at"`
+	// Days finished jobs are kept for. If nil the worker default is used, 0 keeps them forever
+	JobRetentionDays *int `json:"job_retention_days"`
+}
+
+// MaxJobRetentionDays is the largest retention an account can set
+const MaxJobRetentionDays = 3650
+
+type UpdateJobRetentionRequest struct {
+	JobRetentionDays *int `json:"job_retention_days"`
 }
 
 type password struct {
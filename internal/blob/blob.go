@@ -0,0 +1,50 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxSize is the largest blob that can be uploaded, in bytes
+	MaxSize           = 10 << 20
+	MaxFilenameLength = 255
+
+	DefaultContentType = "application/octet-stream"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Blob is a file uploaded by an account, e.g. to be attached to emails.
+// Its content is kept in a [Store], the metadata in the database.
+type Blob struct {
+	ID          string    `json:"id"`
+	AccountID   string    `json:"account_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func New(accountId, filename, contentType string) *Blob {
+	return &Blob{
+		ID:          uuid.NewString(),
+		AccountID:   accountId,
+		Filename:    filename,
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Store keeps the content of the blobs, by their ID.
+type Store interface {
+	// Put stores the content read from r, returning how many bytes were stored.
+	Put(ctx context.Context, id string, r io.Reader) (int64, error)
+	// Open returns the content of the blob, or ErrNotFound.
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	Delete(ctx context.Context, id string) error
+}
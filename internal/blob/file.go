@@ -0,0 +1,87 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileStore keeps blobs in a directory of the local filesystem. The API and the
+// workers must share it, e.g. by running on the same host or mounting a volume.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// path spreads the blobs over subdirectories named after the first characters of their ID.
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id[:2], id)
+}
+
+// Put writes the blob under a temporary name and only renames it once it's
+// complete, so a blob is never read partially written.
+func (s *FileStore) Put(ctx context.Context, id string, r io.Reader) (int64, error) {
+	path := s.path(id)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	// no-op once the file was renamed
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(id))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
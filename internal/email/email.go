@@ -1,7 +1,35 @@
 package email
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidEmailAddress = errors.New("invalid email address")
+)
+
+// Message is an email to be sent. When it has both bodies, the HTML one is sent
+// as an alternative of the text one.
+type Message struct {
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Headers     map[string]string
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
 
 type EmailSender interface {
-	Send(ctx context.Context, to, cc, bcc []string, from, subject, body string) error
+	Send(ctx context.Context, msg *Message) error
 }
@@ -0,0 +1,198 @@
+package email
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/wneessen/go-mail"
+)
+
+// Reason classifies why an email couldn't be sent.
+type Reason string
+
+const (
+	// An address of the email can't be parsed
+	ReasonInvalidAddress Reason = "invalid_address"
+	// The server rejected a recipient, e.g. because the mailbox doesn't exist
+	ReasonInvalidRecipient Reason = "invalid_recipient"
+	// The server rejected the sender
+	ReasonSenderRejected Reason = "sender_rejected"
+	// The server rejected the email itself, e.g. as spam or for being too large
+	ReasonMessageRejected Reason = "message_rejected"
+	ReasonMailboxFull     Reason = "mailbox_full"
+	ReasonAuthFailed      Reason = "auth_failed"
+	ReasonRateLimited     Reason = "rate_limited"
+	ReasonTLSFailed       Reason = "tls_failed"
+	ReasonConnRefused     Reason = "connection_refused"
+	// The server can't be resolved or routed to, or didn't answer in time
+	ReasonHostUnreachable Reason = "host_unreachable"
+	// The connection failed for another reason, e.g. it was closed mid transaction
+	ReasonConnFailed Reason = "connection_failed"
+	// The server failed temporarily for another reason
+	ReasonTemporary Reason = "temporary_failure"
+	// The server rejected the email for another reason
+	ReasonRejected Reason = "rejected"
+)
+
+// Error is an email that couldn't be sent, along with why and whether trying
+// again can succeed.
+type Error struct {
+	Reason Reason
+	// Sending the same email again will fail the same way
+	Permanent bool
+	Err       error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+var (
+	enhancedStatusCodeRX = regexp.MustCompile(`\b[245]\.\d{1,3}\.\d{1,3}\b`)
+	rateLimitedRX        = regexp.MustCompile(`(?i)rate.?limit|too many|throttl`)
+)
+
+// replyCode returns the SMTP reply code and the enhanced status code, if any,
+// of the server reply the error comes from.
+func replyCode(err error) (int, string) {
+	code := 0
+
+	var sendErr *mail.SendError
+	var protoErr *textproto.Error
+	switch {
+	case errors.As(err, &sendErr):
+		code = sendErr.ErrorCode()
+	case errors.As(err, &protoErr):
+		code = protoErr.Code
+	}
+
+	return code, enhancedStatusCodeRX.FindString(err.Error())
+}
+
+// classifyReply classifies an error replied by the server, or returns nil if it's not one.
+// The reason is taken from the reply, falling back to the given one for the
+// command that failed.
+func classifyReply(err error, fallback Reason) *Error {
+	code, enhanced := replyCode(err)
+	if code == 0 {
+		return nil
+	}
+
+	temporary := code/100 == 4
+
+	var reason Reason
+	switch {
+	case temporary && rateLimitedRX.MatchString(err.Error()):
+		reason = ReasonRateLimited
+	case strings.HasSuffix(enhanced, ".2.2") || (fallback == ReasonInvalidRecipient && (code == 452 || code == 552)):
+		reason = ReasonMailboxFull
+		// a full mailbox may be emptied, whatever the server says
+		temporary = true
+	case code == 530 || code == 534 || code == 535 || code == 454 || enhanced == "5.7.8":
+		reason = ReasonAuthFailed
+	case temporary:
+		reason = ReasonTemporary
+	default:
+		reason = fallback
+	}
+
+	return &Error{Reason: reason, Permanent: !temporary, Err: err}
+}
+
+// classifyConnError classifies an error connecting to the server, or returns nil if it's not one.
+func classifyConnError(err error) *Error {
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &certErr) || errors.As(err, &recordErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || strings.Contains(err.Error(), "STARTTLS") || strings.Contains(err.Error(), "tls:") {
+		return &Error{Reason: ReasonTLSFailed, Permanent: true, Err: err}
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return &Error{Reason: ReasonConnRefused, Err: err}
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	if errors.As(err, &dnsErr) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return &Error{Reason: ReasonHostUnreachable, Err: err}
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) || errors.Is(err, mail.ErrNoActiveConnection) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &Error{Reason: ReasonConnFailed, Err: err}
+	}
+
+	return nil
+}
+
+// classifyDialError classifies an error connecting, saying hello or authenticating to the server.
+func classifyDialError(err error) error {
+	if e := classifyConnError(err); e != nil {
+		return e
+	}
+
+	if strings.Contains(err.Error(), "SMTP AUTH") || errors.Is(err, mail.ErrLoginAuthNotSupported) ||
+		errors.Is(err, mail.ErrPlainAuthNotSupported) || errors.Is(err, mail.ErrNoSupportedAuthDiscovered) {
+		e := classifyReply(err, ReasonAuthFailed)
+		if e == nil || e.Reason == ReasonRejected {
+			e = &Error{Reason: ReasonAuthFailed, Permanent: true, Err: err}
+		}
+		return e
+	}
+
+	if e := classifyReply(err, ReasonRejected); e != nil {
+		return e
+	}
+
+	return err
+}
+
+// classifySendError classifies an error sending an email through a connected client.
+func classifySendError(err error) error {
+	var sendErr *mail.SendError
+	if !errors.As(err, &sendErr) {
+		if e := classifyConnError(err); e != nil {
+			return e
+		}
+		return err
+	}
+
+	var fallback Reason
+	switch sendErr.Reason {
+	case mail.ErrGetSender, mail.ErrGetRcpts:
+		return &Error{Reason: ReasonInvalidAddress, Permanent: true, Err: err}
+	case mail.ErrSMTPMailFrom:
+		fallback = ReasonSenderRejected
+	case mail.ErrSMTPRcptTo:
+		fallback = ReasonInvalidRecipient
+	case mail.ErrSMTPData, mail.ErrSMTPDataClose:
+		fallback = ReasonMessageRejected
+	case mail.ErrNoUnencoded:
+		return &Error{Reason: ReasonMessageRejected, Permanent: true, Err: err}
+	default:
+		fallback = ReasonRejected
+	}
+
+	if e := classifyReply(err, fallback); e != nil {
+		return e
+	}
+
+	// the other failures, e.g. writing the content, come from the connection
+	return &Error{Reason: ReasonConnFailed, Err: err}
+}
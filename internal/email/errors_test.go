@@ -0,0 +1,125 @@
+package email
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/wneessen/go-mail"
+)
+
+func TestClassifyConnError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantReason    Reason
+		wantPermanent bool
+	}{
+		{name: "unknown authority", err: x509.UnknownAuthorityError{}, wantReason: ReasonTLSFailed, wantPermanent: true},
+		{name: "hostname mismatch", err: x509.HostnameError{Certificate: &x509.Certificate{}, Host: "smtp.example.com"}, wantReason: ReasonTLSFailed, wantPermanent: true},
+		{name: "refused", err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, wantReason: ReasonConnRefused},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "smtp.example.com", IsNotFound: true}, wantReason: ReasonHostUnreachable},
+		{name: "host unreachable", err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)}, wantReason: ReasonHostUnreachable},
+		{name: "timeout", err: &net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}, wantReason: ReasonHostUnreachable},
+		{name: "reset", err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, wantReason: ReasonConnFailed},
+		{name: "eof", err: fmt.Errorf("reading reply: %w", io.EOF), wantReason: ReasonConnFailed},
+		{name: "no active connection", err: mail.ErrNoActiveConnection, wantReason: ReasonConnFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := classifyConnError(tt.err)
+			if e == nil {
+				t.Fatalf("classifyConnError(%v) = nil, want %s", tt.err, tt.wantReason)
+			}
+			if e.Reason != tt.wantReason || e.Permanent != tt.wantPermanent {
+				t.Errorf("classifyConnError(%v) reason = %s, permanent = %t, want %s, %t",
+					tt.err, e.Reason, e.Permanent, tt.wantReason, tt.wantPermanent)
+			}
+			if !errors.Is(e, tt.err) {
+				t.Errorf("classifyConnError(%v) doesn't wrap the error", tt.err)
+			}
+		})
+	}
+
+	if e := classifyConnError(errors.New("something else")); e != nil {
+		t.Errorf("classifyConnError() of an unrelated error = %v, want nil", e)
+	}
+}
+
+func TestClassifyReply(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		fallback      Reason
+		wantReason    Reason
+		wantPermanent bool
+	}{
+		{name: "permanent uses the fallback", err: &textproto.Error{Code: 550, Msg: "5.1.1 no such user"}, fallback: ReasonInvalidRecipient, wantReason: ReasonInvalidRecipient, wantPermanent: true},
+		{name: "temporary", err: &textproto.Error{Code: 451, Msg: "4.3.0 local error"}, fallback: ReasonRejected, wantReason: ReasonTemporary},
+		{name: "rate limited", err: &textproto.Error{Code: 421, Msg: "4.7.0 rate limit exceeded"}, fallback: ReasonRejected, wantReason: ReasonRateLimited},
+		{name: "mailbox full by enhanced code", err: &textproto.Error{Code: 552, Msg: "5.2.2 mailbox full"}, fallback: ReasonMessageRejected, wantReason: ReasonMailboxFull},
+		{name: "mailbox full by recipient code", err: &textproto.Error{Code: 552, Msg: "quota exceeded"}, fallback: ReasonInvalidRecipient, wantReason: ReasonMailboxFull},
+		{name: "auth by code", err: &textproto.Error{Code: 535, Msg: "bad credentials"}, fallback: ReasonRejected, wantReason: ReasonAuthFailed, wantPermanent: true},
+		{name: "auth by enhanced code", err: &textproto.Error{Code: 550, Msg: "5.7.8 invalid credentials"}, fallback: ReasonRejected, wantReason: ReasonAuthFailed, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := classifyReply(fmt.Errorf("send failed: %w", tt.err), tt.fallback)
+			if e == nil {
+				t.Fatalf("classifyReply(%v) = nil, want %s", tt.err, tt.wantReason)
+			}
+			if e.Reason != tt.wantReason || e.Permanent != tt.wantPermanent {
+				t.Errorf("classifyReply(%v) reason = %s, permanent = %t, want %s, %t",
+					tt.err, e.Reason, e.Permanent, tt.wantReason, tt.wantPermanent)
+			}
+		})
+	}
+
+	if e := classifyReply(errors.New("not a reply"), ReasonRejected); e != nil {
+		t.Errorf("classifyReply() of an error without a reply code = %v, want nil", e)
+	}
+}
+
+func TestClassifySendError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantReason    Reason
+		wantPermanent bool
+	}{
+		{name: "no recipients", err: &mail.SendError{Reason: mail.ErrGetRcpts}, wantReason: ReasonInvalidAddress, wantPermanent: true},
+		{name: "no sender", err: &mail.SendError{Reason: mail.ErrGetSender}, wantReason: ReasonInvalidAddress, wantPermanent: true},
+		{name: "8bit not supported", err: &mail.SendError{Reason: mail.ErrNoUnencoded}, wantReason: ReasonMessageRejected, wantPermanent: true},
+		{name: "writing the content", err: &mail.SendError{Reason: mail.ErrWriteContent}, wantReason: ReasonConnFailed},
+		{name: "connection error", err: &net.OpError{Op: "write", Err: os.NewSyscallError("write", syscall.EPIPE)}, wantReason: ReasonConnFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifySendError(fmt.Errorf("send failed: %w", tt.err))
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("classifySendError(%v) = %v, want an *Error", tt.err, err)
+			}
+			if e.Reason != tt.wantReason || e.Permanent != tt.wantPermanent {
+				t.Errorf("classifySendError(%v) reason = %s, permanent = %t, want %s, %t",
+					tt.err, e.Reason, e.Permanent, tt.wantReason, tt.wantPermanent)
+			}
+		})
+	}
+
+	// unclassified errors are returned as they are, and retried like any other error
+	err := errors.New("something else")
+	if got := classifySendError(err); got != err {
+		t.Errorf("classifySendError(%v) = %v, want the error unchanged", err, got)
+	}
+}
@@ -0,0 +1,119 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// how much of an error response body is part of the error
+const httpErrorBodyLimit = 256
+
+// HTTPProvider translates emails to the requests of an email HTTP API.
+type HTTPProvider interface {
+	NewRequest(ctx context.Context, message *Message) (*http.Request, error)
+	// CheckResponse returns an error if the API didn't accept the email.
+	CheckResponse(resp *http.Response) error
+}
+
+// HTTPSender sends emails through an HTTP API.
+type HTTPSender struct {
+	client   *http.Client
+	provider HTTPProvider
+}
+
+func NewHTTPSender(client *http.Client, provider HTTPProvider) *HTTPSender {
+	return &HTTPSender{client: client, provider: provider}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, message *Message) error {
+	req, err := s.provider.NewRequest(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = s.provider.CheckResponse(resp)
+	// drained so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	return err
+}
+
+// JSONProvider sends emails to a generic JSON API: the email is POSTed to the
+// URL as a JSON object, authenticated with the token as a bearer one, and any
+// 2xx response accepts it.
+type JSONProvider struct {
+	URL   string
+	Token string
+}
+
+type jsonEmail struct {
+	From        string            `json:"from"`
+	To          []string          `json:"to"`
+	Cc          []string          `json:"cc,omitempty"`
+	Bcc         []string          `json:"bcc,omitempty"`
+	ReplyTo     string            `json:"reply_to,omitempty"`
+	Subject     string            `json:"subject"`
+	Text        string            `json:"text,omitempty"`
+	HTML        string            `json:"html,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Attachments []jsonAttachment  `json:"attachments,omitempty"`
+}
+
+type jsonAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	// base64 encoded by encoding/json
+	Content []byte `json:"content"`
+}
+
+func (p *JSONProvider) NewRequest(ctx context.Context, message *Message) (*http.Request, error) {
+	email := jsonEmail{
+		From:    message.From,
+		To:      message.To,
+		Cc:      message.Cc,
+		Bcc:     message.Bcc,
+		ReplyTo: message.ReplyTo,
+		Subject: message.Subject,
+		Text:    message.TextBody,
+		HTML:    message.HTMLBody,
+		Headers: message.Headers,
+	}
+	for _, a := range message.Attachments {
+		email.Attachments = append(email.Attachments, jsonAttachment(a))
+	}
+
+	body, err := json.Marshal(email)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	return req, nil
+}
+
+func (p *JSONProvider) CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, httpErrorBodyLimit))
+	return fmt.Errorf("email API returned %s: %s", resp.Status, bytes.TrimSpace(body))
+}
@@ -0,0 +1,125 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPSenderPostsJSON(t *testing.T) {
+	var got jsonEmail
+	var gotHeader http.Header
+	var gotMethod string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotHeader = r.Header
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := &Config{Provider: "http"}
+	config.HTTP.URL = srv.URL
+	config.HTTP.Token = "api-token"
+
+	s, err := New(slog.New(slog.DiscardHandler), config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	msg := testMessage("Welcome")
+	msg.Cc = []string{"cc@example.com"}
+	msg.HTMLBody = "<p>Hello</p>"
+	msg.Headers = map[string]string{"X-Campaign": "onboarding"}
+	msg.Attachments = []Attachment{{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF")}}
+
+	err = s.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %s, want POST", gotMethod)
+	}
+	if auth := gotHeader.Get("Authorization"); auth != "Bearer api-token" {
+		t.Errorf("Authorization = %q, want the bearer token", auth)
+	}
+	if ct := gotHeader.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	if got.From != msg.From || got.Subject != "Welcome" || got.Text != "Hello" || got.HTML != "<p>Hello</p>" {
+		t.Errorf("email = %+v, want the message fields", got)
+	}
+	if len(got.To) != 1 || got.To[0] != "rcpt@example.com" || len(got.Cc) != 1 || got.Headers["X-Campaign"] != "onboarding" {
+		t.Errorf("email = %+v, want the recipients and headers of the message", got)
+	}
+	if len(got.Attachments) != 1 || got.Attachments[0].Filename != "invoice.pdf" || string(got.Attachments[0].Content) != "%PDF" {
+		t.Errorf("attachments = %+v, want the message attachment", got.Attachments)
+	}
+}
+
+func TestHTTPSenderRejectedResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  string
+		wantSize int
+	}{
+		{name: "short body", status: http.StatusUnprocessableEntity, body: "  invalid recipient\n", wantErr: "email API returned 422 Unprocessable Entity: invalid recipient"},
+		{name: "long body", status: http.StatusInternalServerError, body: strings.Repeat("x", 10*httpErrorBodyLimit), wantSize: httpErrorBodyLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			t.Cleanup(srv.Close)
+
+			s := NewHTTPSender(srv.Client(), &JSONProvider{URL: srv.URL})
+
+			err := s.Send(context.Background(), testMessage("rejected"))
+			if err == nil {
+				t.Fatal("Send() error = nil, want the response as an error")
+			}
+			if tt.wantErr != "" && err.Error() != tt.wantErr {
+				t.Errorf("Send() error = %q, want %q", err, tt.wantErr)
+			}
+			if tt.wantSize != 0 && strings.Count(err.Error(), "x") != tt.wantSize {
+				t.Errorf("Send() error has %d bytes of the body, want %d", strings.Count(err.Error(), "x"), tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestHTTPSenderWithoutToken(t *testing.T) {
+	var auth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewHTTPSender(srv.Client(), &JSONProvider{URL: srv.URL})
+
+	err := s.Send(context.Background(), testMessage("no token"))
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if auth != "" {
+		t.Errorf("Authorization = %q, want none", auth)
+	}
+}
@@ -0,0 +1,28 @@
+package email
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogSender logs emails instead of sending them.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, message *Message) error {
+	filenames := make([]string, 0, len(message.Attachments))
+	for _, a := range message.Attachments {
+		filenames = append(filenames, a.Filename)
+	}
+
+	s.logger.Info("email", "from", message.From, "to", message.To, "cc", message.Cc, "bcc", message.Bcc,
+		"subject", message.Subject, "attachments", filenames)
+	s.logger.Debug("email body", "subject", message.Subject, "text", message.TextBody, "html", message.HTMLBody)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogSender(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     slog.Level
+		wantBody  bool
+		wantLines int
+	}{
+		{name: "info", level: slog.LevelInfo, wantLines: 1},
+		{name: "debug", level: slog.LevelDebug, wantBody: true, wantLines: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			s := NewLogSender(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: tt.level})))
+
+			msg := testMessage("Welcome")
+			msg.TextBody = "secret-ish body"
+			msg.Attachments = []Attachment{{Filename: "invoice.pdf", Content: []byte("%PDF")}}
+
+			err := s.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			out := buf.String()
+			if lines := strings.Count(out, "\n"); lines != tt.wantLines {
+				t.Errorf("logged %d lines, want %d:\n%s", lines, tt.wantLines, out)
+			}
+			for _, want := range []string{"subject=Welcome", "rcpt@example.com", "invoice.pdf"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("log doesn't contain %q:\n%s", want, out)
+				}
+			}
+			if got := strings.Contains(out, "secret-ish body"); got != tt.wantBody {
+				t.Errorf("body logged = %t, want %t:\n%s", got, tt.wantBody, out)
+			}
+			if strings.Contains(out, "%PDF") {
+				t.Errorf("log contains the attachment content:\n%s", out)
+			}
+		})
+	}
+}
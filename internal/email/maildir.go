@@ -0,0 +1,67 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaildirSender delivers emails to a maildir instead of sending them, so they
+// can be read with a mail client during local development.
+type MaildirSender struct {
+	dir      string
+	hostname string
+}
+
+// NewMaildirSender returns a sender for the maildir, creating it if it doesn't exist.
+func NewMaildirSender(dir string) (*MaildirSender, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0o750)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &MaildirSender{dir: dir, hostname: hostname}, nil
+}
+
+// Send writes the email to the tmp directory of the maildir and then moves it
+// to new, so readers never see a partially written email.
+func (s *MaildirSender) Send(ctx context.Context, message *Message) error {
+	msg, err := newMsg(message)
+	if err != nil {
+		return err
+	}
+	msg.SetDate()
+	msg.SetMessageID()
+
+	name := fmt.Sprintf("%d.%s.%s", time.Now().UnixNano(), rand.Text(), s.hostname)
+	tmpPath := filepath.Join(s.dir, "tmp", name)
+
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+
+	_, err = msg.WriteTo(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(s.dir, "new", name))
+}
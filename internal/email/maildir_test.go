@@ -0,0 +1,85 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMaildirSenderDeliversToNew(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "maildir")
+
+	s, err := NewMaildirSender(dir)
+	if err != nil {
+		t.Fatalf("NewMaildirSender() error = %v", err)
+	}
+
+	msg := testMessage("Welcome")
+	msg.HTMLBody = "<p>Hello</p>"
+	msg.Attachments = []Attachment{{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF")}}
+
+	for range 2 {
+		err = s.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	newEntries, err := os.ReadDir(filepath.Join(dir, "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newEntries) != 2 {
+		t.Fatalf("new has %d emails, want 2", len(newEntries))
+	}
+
+	for _, sub := range []string{"tmp", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("%s has %d files, want none", sub, len(entries))
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "new", newEntries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	email := string(data)
+
+	for _, want := range []string{"Subject: Welcome", "To: <rcpt@example.com>", "Message-ID:", "Date:", "multipart/alternative", `filename="invoice.pdf"`} {
+		if !strings.Contains(email, want) {
+			t.Errorf("email doesn't contain %q:\n%s", want, email)
+		}
+	}
+}
+
+func TestMaildirSenderRejectsInvalidAddress(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewMaildirSender(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := testMessage("invalid")
+	msg.From = "not an address"
+
+	err = s.Send(context.Background(), msg)
+	if !errors.Is(err, ErrInvalidEmailAddress) {
+		t.Fatalf("Send() error = %v, want ErrInvalidEmailAddress", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("new has %d emails, want none", len(entries))
+	}
+}
@@ -0,0 +1,71 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/wneessen/go-mail"
+)
+
+// newMsg builds the MIME message of the email, for the senders that deliver it as such.
+func newMsg(message *Message) (*mail.Msg, error) {
+	msg := mail.NewMsg()
+	var allErrors []error
+
+	err := msg.From(message.From)
+	if err != nil {
+		allErrors = append(allErrors, fmt.Errorf("%w: %s", ErrInvalidEmailAddress, message.From))
+	}
+	err = msg.To(message.To...)
+	if err != nil {
+		allErrors = append(allErrors, fmt.Errorf("%w: %s", ErrInvalidEmailAddress, message.To))
+	}
+	if len(message.Cc) > 0 {
+		err = msg.Cc(message.Cc...)
+		if err != nil {
+			allErrors = append(allErrors, fmt.Errorf("%w: %s", ErrInvalidEmailAddress, message.Cc))
+		}
+	}
+	if len(message.Bcc) > 0 {
+		err = msg.Bcc(message.Bcc...)
+		if err != nil {
+			allErrors = append(allErrors, fmt.Errorf("%w: %s", ErrInvalidEmailAddress, message.Bcc))
+		}
+	}
+	if message.ReplyTo != "" {
+		err = msg.ReplyTo(message.ReplyTo)
+		if err != nil {
+			allErrors = append(allErrors, fmt.Errorf("%w: %s", ErrInvalidEmailAddress, message.ReplyTo))
+		}
+	}
+
+	if len(allErrors) > 0 {
+		return nil, &Error{Reason: ReasonInvalidAddress, Permanent: true, Err: errors.Join(allErrors...)}
+	}
+
+	msg.Subject(message.Subject)
+
+	for name, value := range message.Headers {
+		msg.SetGenHeader(mail.Header(name), value)
+	}
+
+	switch {
+	case message.TextBody != "" && message.HTMLBody != "":
+		msg.SetBodyString(mail.TypeTextPlain, message.TextBody)
+		msg.AddAlternativeString(mail.TypeTextHTML, message.HTMLBody)
+	case message.HTMLBody != "":
+		msg.SetBodyString(mail.TypeTextHTML, message.HTMLBody)
+	default:
+		msg.SetBodyString(mail.TypeTextPlain, message.TextBody)
+	}
+
+	for _, a := range message.Attachments {
+		err := msg.AttachReader(a.Filename, bytes.NewReader(a.Content), mail.WithFileContentType(mail.ContentType(a.ContentType)))
+		if err != nil {
+			return nil, fmt.Errorf("attaching %q: %w", a.Filename, err)
+		}
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,102 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config selects the provider the emails are sent with and configures it.
+type Config struct {
+	Provider string
+	SMTP     SMTPConfig
+	HTTP     struct {
+		URL     string
+		Token   string
+		Timeout time.Duration
+	}
+	MaildirDir string
+}
+
+// Provider returns a sender configured by the config.
+type Provider func(logger *slog.Logger, config *Config) (EmailSender, error)
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{
+		"smtp":    newSMTPProvider,
+		"http":    newHTTPProvider,
+		"maildir": newMaildirProvider,
+		"log":     newLogProvider,
+	}
+)
+
+// Register makes a provider available by name. It panics if the name is taken.
+func Register(name string, provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if _, ok := providers[name]; ok {
+		panic(fmt.Sprintf("email: provider %q registered twice", name))
+	}
+	providers[name] = provider
+}
+
+// Providers returns the names of the registered providers, sorted.
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(providers))
+}
+
+// New returns a sender of the provider selected by the config.
+func New(logger *slog.Logger, config *Config) (EmailSender, error) {
+	providersMu.RLock()
+	provider, ok := providers[config.Provider]
+	providersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown email provider %q, must be one of %s", config.Provider, strings.Join(Providers(), ", "))
+	}
+
+	return provider(logger, config)
+}
+
+func newSMTPProvider(logger *slog.Logger, config *Config) (EmailSender, error) {
+	if config.SMTP.Host == "" {
+		return nil, errors.New("smtp host is required by the smtp email provider")
+	}
+	return NewSMTPSender(&config.SMTP)
+}
+
+func newHTTPProvider(logger *slog.Logger, config *Config) (EmailSender, error) {
+	if config.HTTP.URL == "" {
+		return nil, errors.New("url is required by the http email provider")
+	}
+
+	timeout := config.HTTP.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return NewHTTPSender(client, &JSONProvider{URL: config.HTTP.URL, Token: config.HTTP.Token}), nil
+}
+
+func newMaildirProvider(logger *slog.Logger, config *Config) (EmailSender, error) {
+	if config.MaildirDir == "" {
+		return nil, errors.New("dir is required by the maildir email provider")
+	}
+	return NewMaildirSender(config.MaildirDir)
+}
+
+func newLogProvider(logger *slog.Logger, config *Config) (EmailSender, error) {
+	return NewLogSender(logger), nil
+}
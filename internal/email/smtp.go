@@ -0,0 +1,179 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wneessen/go-mail"
+	"github.com/wneessen/go-mail/smtp"
+)
+
+const (
+	DefaultSMTPMaxIdleConns = 2
+	// servers usually drop idle connections after a few minutes, so they are closed before
+	smtpIdleTimeout = time.Minute
+)
+
+// SMTPConfig is the SMTP server a sender connects to.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// How many connections are kept open between messages, DefaultSMTPMaxIdleConns when not set
+	MaxIdleConns int
+	// Used to connect to the server instead of the default dialer when set
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+type smtpConn struct {
+	client    *smtp.Client
+	idleSince time.Time
+}
+
+// SMTPSender sends emails through an SMTP server. Connections are reused
+// between messages, instead of dialing the server for each of them.
+type SMTPSender struct {
+	client       *mail.Client
+	maxIdleConns int
+
+	mu   sync.Mutex
+	idle []*smtpConn
+}
+
+// NewSMTPSender returns a sender for the SMTP server, e.g. the one of an account.
+// The server is only authenticated with when the config has a username.
+func NewSMTPSender(config *SMTPConfig) (*SMTPSender, error) {
+	opts := []mail.Option{
+		mail.WithPort(config.Port),
+		mail.WithTimeout(5 * time.Second),
+	}
+	if config.Username != "" {
+		opts = append(opts,
+			mail.WithSMTPAuth(mail.SMTPAuthLogin),
+			mail.WithUsername(config.Username),
+			mail.WithPassword(config.Password),
+		)
+	}
+	if config.DialContext != nil {
+		opts = append(opts, mail.WithDialContextFunc(config.DialContext))
+	}
+
+	client, err := mail.NewClient(config.Host, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultSMTPMaxIdleConns
+	}
+
+	return &SMTPSender{client: client, maxIdleConns: maxIdleConns}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message *Message) error {
+	msg, err := newMsg(message)
+	if err != nil {
+		return err
+	}
+
+	conn, pooled, err := s.conn(ctx)
+	if err != nil {
+		return classifyDialError(fmt.Errorf("dial failed: %w", err))
+	}
+
+	err = s.client.SendWithSMTPClient(conn, msg)
+
+	// the server may have closed the pooled connection while it was idle
+	var sendErr *mail.SendError
+	if pooled && errors.As(err, &sendErr) && sendErr.Reason == mail.ErrConnCheck {
+		s.close(conn)
+
+		conn, err = s.client.DialToSMTPClientWithContext(ctx)
+		if err != nil {
+			return classifyDialError(fmt.Errorf("dial failed: %w", err))
+		}
+		err = s.client.SendWithSMTPClient(conn, msg)
+	}
+
+	if err != nil {
+		s.close(conn)
+		// the connection failed after the server accepted the email, e.g. resetting the session for the next one
+		if msg.IsDelivered() {
+			return nil
+		}
+		return classifySendError(fmt.Errorf("send failed: %w", err))
+	}
+
+	s.release(conn)
+
+	return nil
+}
+
+// Close closes the idle connections. The sender can still be used after it.
+func (s *SMTPSender) Close() error {
+	s.mu.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for _, c := range idle {
+		errs = append(errs, s.client.CloseWithSMTPClient(c.client))
+	}
+	return errors.Join(errs...)
+}
+
+// conn returns an idle connection, or dials a new one if there are none. It
+// reports whether the connection was idle.
+func (s *SMTPSender) conn(ctx context.Context) (*smtp.Client, bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	var conn *smtpConn
+	for len(s.idle) > 0 && conn == nil {
+		// the most recently used connection is the least likely to have been dropped
+		c := s.idle[len(s.idle)-1]
+		s.idle = s.idle[:len(s.idle)-1]
+
+		if now.Sub(c.idleSince) < smtpIdleTimeout {
+			conn = c
+		} else {
+			go s.close(c.client)
+		}
+	}
+	s.mu.Unlock()
+
+	if conn != nil {
+		return conn.client, true, nil
+	}
+
+	client, err := s.client.DialToSMTPClientWithContext(ctx)
+	return client, false, err
+}
+
+// release puts the connection back in the pool, or closes it if the pool is full.
+func (s *SMTPSender) release(client *smtp.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.idle) >= s.maxIdleConns {
+		go s.close(client)
+		return
+	}
+
+	s.idle = append(s.idle, &smtpConn{client: client, idleSince: time.Now()})
+}
+
+func (s *SMTPSender) close(client *smtp.Client) {
+	err := s.client.CloseWithSMTPClient(client)
+	if err != nil {
+		// QUIT failing means the connection is already unusable, so it's just dropped
+		_ = client.Close()
+	}
+}
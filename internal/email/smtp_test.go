@@ -0,0 +1,334 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wneessen/go-mail"
+)
+
+// smtpServer is an in-process SMTP server accepting the emails sent to it,
+// unless the reply to one of the commands is overridden.
+type smtpServer struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	accepted int
+	messages []string
+	// command, e.g. RCPT, to the reply sent instead of accepting it
+	replies map[string]string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &smtpServer{ln: ln, replies: map[string]string{}}
+	go s.serve()
+
+	t.Cleanup(func() {
+		ln.Close()
+		s.dropConns()
+	})
+
+	return s
+}
+
+func (s *smtpServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+// reply overrides the reply to the command.
+func (s *smtpServer) reply(command, reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replies[command] = reply
+}
+
+// dropConns closes the open connections, like a server dropping idle clients.
+func (s *smtpServer) dropConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.conns {
+		c.Close()
+	}
+	s.conns = nil
+}
+
+func (s *smtpServer) stats() (accepted int, messages []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accepted, s.messages
+}
+
+func (s *smtpServer) serve() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, c)
+		s.accepted++
+		s.mu.Unlock()
+
+		go s.handle(c)
+	}
+}
+
+func (s *smtpServer) handle(c net.Conn) {
+	defer c.Close()
+
+	tp := textproto.NewConn(c)
+	tp.PrintfLine("220 127.0.0.1 ESMTP test")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		command, _, _ := strings.Cut(line, " ")
+		command = strings.ToUpper(command)
+
+		s.mu.Lock()
+		reply, ok := s.replies[command]
+		s.mu.Unlock()
+		if ok {
+			tp.PrintfLine("%s", reply)
+			continue
+		}
+
+		switch command {
+		case "EHLO":
+			tp.PrintfLine("250-127.0.0.1\r\n250-8BITMIME\r\n250-ENHANCEDSTATUSCODES\r\n250 AUTH LOGIN PLAIN")
+		case "HELO", "MAIL", "RCPT", "RSET", "NOOP":
+			tp.PrintfLine("250 2.0.0 OK")
+		case "AUTH":
+			// LOGIN, asking for the username and then the password
+			tp.PrintfLine("334 VXNlcm5hbWU6")
+			tp.ReadLine()
+			tp.PrintfLine("334 UGFzc3dvcmQ6")
+			tp.ReadLine()
+			tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			tp.PrintfLine("250 2.0.0 queued")
+		case "QUIT":
+			tp.PrintfLine("221 2.0.0 bye")
+			return
+		default:
+			tp.PrintfLine("502 5.5.2 command not recognized")
+		}
+	}
+}
+
+// newTestSMTPSender returns a sender of the server, without STARTTLS as the server doesn't support it.
+func newTestSMTPSender(t *testing.T, srv *smtpServer, config SMTPConfig) *SMTPSender {
+	t.Helper()
+
+	config.Host = "127.0.0.1"
+	config.Port = srv.port()
+
+	s, err := NewSMTPSender(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.client.SetTLSPolicy(mail.NoTLS)
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func testMessage(subject string) *Message {
+	return &Message{From: "sender@example.com", To: []string{"rcpt@example.com"}, Subject: subject, TextBody: "Hello"}
+}
+
+func TestSMTPSenderReusesConnection(t *testing.T) {
+	srv := newSMTPServer(t)
+	s := newTestSMTPSender(t, srv, SMTPConfig{})
+
+	for i := range 3 {
+		err := s.Send(context.Background(), testMessage("email "+strconv.Itoa(i)))
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	accepted, messages := srv.stats()
+	if accepted != 1 {
+		t.Errorf("server accepted %d connections, want 1", accepted)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("server got %d emails, want 3", len(messages))
+	}
+	if !strings.Contains(messages[2], "Subject: email 2") {
+		t.Errorf("last email = %q, want the subject of the last one sent", messages[2])
+	}
+}
+
+func TestSMTPSenderRedialsBrokenIdleConnection(t *testing.T) {
+	srv := newSMTPServer(t)
+	s := newTestSMTPSender(t, srv, SMTPConfig{})
+
+	err := s.Send(context.Background(), testMessage("first"))
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// the pooled connection breaks while idle
+	srv.dropConns()
+
+	err = s.Send(context.Background(), testMessage("second"))
+	if err != nil {
+		t.Fatalf("Send() after the connection broke error = %v", err)
+	}
+
+	accepted, messages := srv.stats()
+	if accepted != 2 {
+		t.Errorf("server accepted %d connections, want 2", accepted)
+	}
+	if len(messages) != 2 {
+		t.Errorf("server got %d emails, want 2", len(messages))
+	}
+}
+
+func TestSMTPSenderCloseDropsIdleConnections(t *testing.T) {
+	srv := newSMTPServer(t)
+	s := newTestSMTPSender(t, srv, SMTPConfig{})
+
+	for _, subject := range []string{"before close", "after close"} {
+		err := s.Send(context.Background(), testMessage(subject))
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		err = s.Close()
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	accepted, _ := srv.stats()
+	if accepted != 2 {
+		t.Errorf("server accepted %d connections, want 2", accepted)
+	}
+}
+
+func TestSMTPSenderClassifiesReplies(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       string
+		reply         string
+		username      string
+		wantReason    Reason
+		wantPermanent bool
+	}{
+		{name: "unknown recipient", command: "RCPT", reply: "550 5.1.1 no such user", wantReason: ReasonInvalidRecipient, wantPermanent: true},
+		{name: "mailbox full", command: "RCPT", reply: "452 4.2.2 mailbox full", wantReason: ReasonMailboxFull},
+		{name: "sender rejected", command: "MAIL", reply: "553 5.7.1 sender not allowed", wantReason: ReasonSenderRejected, wantPermanent: true},
+		{name: "rate limited", command: "MAIL", reply: "450 4.7.1 too many messages, slow down", wantReason: ReasonRateLimited},
+		{name: "message rejected", command: "DATA", reply: "554 5.7.1 message looks like spam", wantReason: ReasonMessageRejected, wantPermanent: true},
+		{name: "temporary failure", command: "DATA", reply: "451 4.3.0 try again later", wantReason: ReasonTemporary},
+		{name: "auth failed", command: "AUTH", reply: "535 5.7.8 authentication credentials invalid", username: "user", wantReason: ReasonAuthFailed, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newSMTPServer(t)
+			srv.reply(tt.command, tt.reply)
+			s := newTestSMTPSender(t, srv, SMTPConfig{Username: tt.username, Password: "password"})
+
+			err := s.Send(context.Background(), testMessage("rejected"))
+
+			var emailErr *Error
+			if !errors.As(err, &emailErr) {
+				t.Fatalf("Send() error = %v, want an *Error", err)
+			}
+			if emailErr.Reason != tt.wantReason || emailErr.Permanent != tt.wantPermanent {
+				t.Errorf("Send() error reason = %s, permanent = %t, want %s, %t (%v)",
+					emailErr.Reason, emailErr.Permanent, tt.wantReason, tt.wantPermanent, err)
+			}
+		})
+	}
+}
+
+func TestSMTPSenderClassifiesMissingSTARTTLS(t *testing.T) {
+	srv := newSMTPServer(t)
+
+	s, err := NewSMTPSender(&SMTPConfig{Host: "127.0.0.1", Port: srv.port()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Send(context.Background(), testMessage("plaintext"))
+
+	var emailErr *Error
+	if !errors.As(err, &emailErr) || emailErr.Reason != ReasonTLSFailed || !emailErr.Permanent {
+		t.Errorf("Send() error = %v, want a permanent %s error", err, ReasonTLSFailed)
+	}
+}
+
+func TestSMTPSenderClassifiesRefusedConnection(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	s, err := NewSMTPSender(&SMTPConfig{Host: "127.0.0.1", Port: port})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Send(context.Background(), testMessage("nobody listening"))
+
+	var emailErr *Error
+	if !errors.As(err, &emailErr) || emailErr.Reason != ReasonConnRefused || emailErr.Permanent {
+		t.Errorf("Send() error = %v, want a temporary %s error", err, ReasonConnRefused)
+	}
+}
+
+func TestSMTPSenderRejectsInvalidAddressWithoutDialing(t *testing.T) {
+	srv := newSMTPServer(t)
+	s := newTestSMTPSender(t, srv, SMTPConfig{})
+
+	msg := testMessage("invalid")
+	msg.To = []string{"not an address"}
+
+	err := s.Send(context.Background(), msg)
+
+	var emailErr *Error
+	if !errors.As(err, &emailErr) || emailErr.Reason != ReasonInvalidAddress || !emailErr.Permanent {
+		t.Errorf("Send() error = %v, want a permanent %s error", err, ReasonInvalidAddress)
+	}
+	if !errors.Is(err, ErrInvalidEmailAddress) {
+		t.Errorf("Send() error = %v, want it to wrap ErrInvalidEmailAddress", err)
+	}
+
+	if accepted, _ := srv.stats(); accepted != 0 {
+		t.Errorf("server accepted %d connections, want 0", accepted)
+	}
+}
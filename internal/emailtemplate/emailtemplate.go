@@ -0,0 +1,180 @@
+package emailtemplate
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNameLength    = 64
+	MaxSubjectLength = 998
+	MaxBodyLength    = 256 * 1024
+)
+
+// Template is an email template of an account. The subject and text body are
+// text/template templates and the HTML body a html/template one, so the data
+// rendered in it is escaped.
+type Template struct {
+	ID        string    `json:"id"`
+	AccountID string    `json:"account_id"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	TextBody  string    `json:"text_body,omitempty"`
+	HTMLBody  string    `json:"html_body,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func New(accountId string, request *Request) *Template {
+	now := time.Now()
+
+	return &Template{
+		ID:        uuid.NewString(),
+		AccountID: accountId,
+		Name:      request.Name,
+		Subject:   request.Subject,
+		TextBody:  request.TextBody,
+		HTMLBody:  request.HTMLBody,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Request creates a template or replaces all the fields of an existing one.
+type Request struct {
+	Name     string `json:"name"`
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
+
+// Rendered is the result of rendering a template with the data of an email.
+type Rendered struct {
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Field names of the parse errors, matching the request fields
+const (
+	FieldSubject  = "subject"
+	FieldTextBody = "text_body"
+	FieldHTMLBody = "html_body"
+)
+
+// Error is an error parsing or rendering one of the template fields.
+type Error struct {
+	Field string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrTooLong is the error of rendering a field past its maximum length.
+var ErrTooLong = errors.New("rendered too long")
+
+// limitedWriter fails the writes past max bytes, so a template expanding the
+// data, e.g. in a range, stops rendering instead of growing without bounds.
+type limitedWriter struct {
+	strings.Builder
+	max int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.Len()+len(p) > w.max {
+		return 0, fmt.Errorf("%w, must not be more than %d bytes long", ErrTooLong, w.max)
+	}
+
+	return w.Builder.Write(p)
+}
+
+type parsed struct {
+	subject  *texttemplate.Template
+	textBody *texttemplate.Template
+	htmlBody *htmltemplate.Template
+}
+
+// parse parses the template fields. Data without a key used by the template
+// fails the render, instead of leaving "<no value>" in the email.
+func (t *Template) parse() (*parsed, error) {
+	var p parsed
+	var err error
+
+	p.subject, err = texttemplate.New(FieldSubject).Option("missingkey=error").Parse(t.Subject)
+	if err != nil {
+		return nil, &Error{Field: FieldSubject, Err: err}
+	}
+
+	if t.TextBody != "" {
+		p.textBody, err = texttemplate.New(FieldTextBody).Option("missingkey=error").Parse(t.TextBody)
+		if err != nil {
+			return nil, &Error{Field: FieldTextBody, Err: err}
+		}
+	}
+
+	if t.HTMLBody != "" {
+		p.htmlBody, err = htmltemplate.New(FieldHTMLBody).Option("missingkey=error").Parse(t.HTMLBody)
+		if err != nil {
+			return nil, &Error{Field: FieldHTMLBody, Err: err}
+		}
+	}
+
+	return &p, nil
+}
+
+// Validate checks that all the template fields can be parsed.
+func (t *Template) Validate() error {
+	_, err := t.parse()
+	return err
+}
+
+// Render renders the template fields with the given data. A field rendering
+// past its maximum length fails with an ErrTooLong error.
+func (t *Template) Render(data map[string]any) (*Rendered, error) {
+	p, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var r Rendered
+
+	subject := &limitedWriter{max: MaxSubjectLength}
+	err = p.subject.Execute(subject, data)
+	if err != nil {
+		return nil, &Error{Field: FieldSubject, Err: err}
+	}
+	// a subject can't span more than one line
+	r.Subject = strings.Join(strings.Fields(subject.String()), " ")
+
+	if p.textBody != nil {
+		textBody := &limitedWriter{max: MaxBodyLength}
+		err = p.textBody.Execute(textBody, data)
+		if err != nil {
+			return nil, &Error{Field: FieldTextBody, Err: err}
+		}
+		r.TextBody = textBody.String()
+	}
+
+	if p.htmlBody != nil {
+		htmlBody := &limitedWriter{max: MaxBodyLength}
+		err = p.htmlBody.Execute(htmlBody, data)
+		if err != nil {
+			return nil, &Error{Field: FieldHTMLBody, Err: err}
+		}
+		r.HTMLBody = htmlBody.String()
+	}
+
+	return &r, nil
+}
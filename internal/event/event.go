@@ -0,0 +1,70 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/task"
+)
+
+// Event records a status transition of a job.
+// IDs are assigned by the store and are increasing, so they can be used by
+// clients to resume a stream from the last event they have seen. Events are
+// published by several processes though, so they can be delivered live out
+// of ID order.
+type Event struct {
+	ID             int64      `json:"id"`
+	JobID          string     `json:"job_id"`
+	AccountID      string     `json:"account_id"`
+	Task           task.Task  `json:"task"`
+	Status         job.Status `json:"status"`
+	PreviousStatus job.Status `json:"previous_status,omitempty"` // Empty when the job was just created
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type Filter struct {
+	AccountID string
+	Task      task.Task
+	Status    job.Status
+	JobID     string
+	// Only events with an ID greater than this one are matched, e.g. the
+	// last one replayed from the store
+	AfterID int64
+}
+
+// Matches reports whether the given [Event] satisfies every field set in the filter.
+func (f *Filter) Matches(e *Event) bool {
+	if e.AccountID != f.AccountID {
+		return false
+	}
+	if e.ID <= f.AfterID {
+		return false
+	}
+	if f.Task != "" && e.Task != f.Task {
+		return false
+	}
+	if f.Status != "" && e.Status != f.Status {
+		return false
+	}
+	if f.JobID != "" && e.JobID != f.JobID {
+		return false
+	}
+
+	return true
+}
+
+// Broker fans out job events to every process interested in them.
+// Delivery is best effort: subscribers that need every event should
+// replay them from the store.
+type Broker interface {
+	Publish(ctx context.Context, e *Event) error
+	Subscribe(ctx context.Context, accountId string) (Subscription, error)
+}
+
+type Subscription interface {
+	// Events returns the channel on which events are delivered.
+	// The channel is closed when the subscription is closed.
+	Events() <-chan *Event
+	Close() error
+}
@@ -0,0 +1,90 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// events are published on one channel per account so subscribers only receive their own
+const channelPrefix = "job_events:"
+
+type RedisBroker struct {
+	Redis  *redis.Client
+	logger *slog.Logger
+}
+
+func NewRedisBroker(logger *slog.Logger, redis *redis.Client) *RedisBroker {
+	return &RedisBroker{
+		Redis:  redis,
+		logger: logger,
+	}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, e *Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return b.Redis.Publish(ctx, channelPrefix+e.AccountID, data).Err()
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, accountId string) (Subscription, error) {
+	pubsub := b.Redis.Subscribe(ctx, channelPrefix+accountId)
+
+	// Wait for the subscription to be confirmed so no event published after
+	// this method returns is missed.
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	sub := &redisSubscription{
+		pubsub: pubsub,
+		events: make(chan *Event),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(sub.events)
+
+		for msg := range pubsub.Channel() {
+			var e Event
+			err := json.Unmarshal([]byte(msg.Payload), &e)
+			if err != nil {
+				b.logger.Error("failed to decode job event", "channel", msg.Channel, "err", err.Error())
+				continue
+			}
+
+			select {
+			case sub.events <- &e:
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+type redisSubscription struct {
+	pubsub *redis.PubSub
+	events chan *Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (s *redisSubscription) Events() <-chan *Event {
+	return s.events
+}
+
+func (s *redisSubscription) Close() error {
+	// unblock the forwarding goroutine in case nobody is reading the events anymore
+	s.once.Do(func() { close(s.done) })
+	return s.pubsub.Close()
+}
@@ -19,33 +19,67 @@ const (
 	StatusDone      Status = "Done"
 	StatusFailed    Status = "Failed"
 	StatusCancelled Status = "Cancelled"
+	StatusExpired   Status = "Expired" // The job wasn't run because its deadline passed
+	// The job wasn't run because it targets a suppressed address, e.g. an email to one that bounced
+	StatusSuppressed Status = "Suppressed"
 )
 
-var StatusList = []Status{StatusCreated, StatusQueued, StatusRunning, StatusDone, StatusFailed, StatusCancelled}
+var StatusList = []Status{StatusCreated, StatusQueued, StatusRunning, StatusDone, StatusFailed, StatusCancelled, StatusExpired, StatusSuppressed}
+
+// Statuses of a finished job, which can be purged once its retention passes.
+// Failed isn't terminal though, the job can be scheduled to run again.
+var FinalStatusList = []Status{StatusDone, StatusFailed, StatusCancelled, StatusExpired, StatusSuppressed}
 
 var allowedStatusTransitions = map[Status][]Status{
-	StatusCreated:   {StatusQueued},
-	StatusQueued:    {StatusRunning, StatusCancelled},
-	StatusRunning:   {StatusDone, StatusFailed},
-	StatusDone:      {},
-	StatusFailed:    {StatusQueued},
-	StatusCancelled: {},
+	StatusCreated:    {StatusQueued},
+	StatusQueued:     {StatusRunning, StatusCancelled, StatusExpired},
+	StatusRunning:    {StatusDone, StatusFailed, StatusExpired, StatusSuppressed},
+	StatusDone:       {},
+	StatusFailed:     {StatusQueued},
+	StatusCancelled:  {},
+	StatusExpired:    {},
+	StatusSuppressed: {},
 }
 
 const DefaultRetryDelay = 60
 
+const MaxSearchQueryLength = 256
+
+const MaxIdempotencyKeyLength = 255
+
+const (
+	MaxTags                = 20
+	MaxTagLength           = 64
+	MaxMetadataKeys        = 20
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 256
+)
+
 type Job struct {
-	ID            string          `json:"id"`
-	Task          task.Task       `json:"task"`
-	Payload       json.RawMessage `json:"payload"`
-	RunAt         *time.Time      `json:"run_at,omitempty"`
-	Status        Status          `json:"status"`
-	CreatedAt     time.Time       `json:"created_at"`
-	FinishedAt    *time.Time      `json:"finished_at,omitempty"` // When the job finished execution (either with success or not)
-	Retries       int             `json:"retries"`               // How many times the job has already been retried
-	MaxRetries    int             `json:"max_retries"`           // Maximum number of retry attempts allowed for the job
-	RetryDelaySec int             `json:"retry_delay_sec"`       // Interval in seconds between each retry
-	LastError     *string         `json:"last_error,omitempty"`  // Stores the last error message encountered when running the job
+	ID            string            `json:"id"`
+	AccountID     string            `json:"account_id"`
+	Task          task.Task         `json:"task"`
+	Payload       json.RawMessage   `json:"payload"`
+	RunAt         *time.Time        `json:"run_at,omitempty"`
+	Status        Status            `json:"status"`
+	CreatedAt     time.Time         `json:"created_at"`
+	FinishedAt    *time.Time        `json:"finished_at,omitempty"` // When the job finished execution (either with success or not)
+	Retries       int               `json:"retries"`               // How many times the job has already been retried
+	MaxRetries    int               `json:"max_retries"`           // Maximum number of retry attempts allowed for the job
+	RetryDelaySec int               `json:"retry_delay_sec"`       // Interval in seconds between each retry
+	LastError     *string           `json:"last_error,omitempty"`  // Stores the last error message encountered when running the job
+	Tags          []string          `json:"tags"`
+	Metadata      map[string]string `json:"metadata"`
+	ExpiresAt     *time.Time        `json:"expires_at,omitempty"`   // The job is not run nor retried after this time
+	Progress      *Progress         `json:"progress,omitempty"`     // Last progress reported while running
+	HeartbeatAt   *time.Time        `json:"heartbeat_at,omitempty"` // Updated periodically by the worker while running
+	// Classification of the last error, e.g. invalid_recipient when an email was rejected
+	LastErrorCode *string `json:"last_error_code,omitempty"`
+	// Set while an external job is leased, see [Lease]
+	LeaseID        *string    `json:"-"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+	// Sent by the client that created the job so retrying the request doesn't create another
+	IdempotencyKey *string `json:"-"`
 }
 
 type CreateRequest struct {
@@ -55,6 +89,14 @@ type CreateRequest struct {
 	RunAt         *time.Time `json:"run_at,omitempty"`
 	MaxRetries    *int       `json:"max_retries"`
 	RetryDelaySec *int       `json:"retry_delay_sec"`
+	// Free form labels and key/value pairs that jobs can be searched by
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// If set, the job is expired instead of run when it's dequeued after this time
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Read from the Idempotency-Key header. Requests with the key of a job already
+	// created return that job instead of creating another
+	IdempotencyKey string `json:"-"`
 }
 
 // This type is for "internal" update requests only.
@@ -73,8 +115,23 @@ type UpdateFields struct {
 	SetRetries bool
 	Retries    *int
 
-	SetLastError bool
-	LastError    *string
+	SetLastError  bool
+	LastError     *string
+	LastErrorCode *string
+
+	// Ends the lease of an external job
+	ClearLease bool
+
+	// When set, the job is only updated while it's running under this lease
+	// and, if LeaseExpiredBefore is set too, the lease expired before then, so
+	// a leased attempt isn't finished twice
+	LeaseID            *string
+	LeaseExpiredBefore *time.Time
+}
+
+// IsTerminal reports whether a job in the status can't change status anymore.
+func IsTerminal(status Status) bool {
+	return len(allowedStatusTransitions[status]) == 0
 }
 
 func IsValidStatusTransition(from Status, to Status) bool {
@@ -87,9 +144,39 @@ func IsValidStatusTransition(from Status, to Status) bool {
 }
 
 type SearchCriteria struct {
-	Task      task.Task
-	RunBefore *time.Time
-	RunAfter  *time.Time
-	Status    Status
+	// Only the jobs of this account are matched
+	AccountID      string
+	Task           task.Task
+	RunBefore      *time.Time
+	RunAfter       *time.Time
+	CreatedBefore  *time.Time
+	CreatedAfter   *time.Time
+	FinishedBefore *time.Time
+	FinishedAfter  *time.Time
+	// Jobs must have one of the given statuses
+	Statuses   []Status
+	RetriesGTE *int
+	HasError   *bool
+	// Text searched in the last error and in the payload
+	Query string
+	// Jobs whose last heartbeat is older than this, e.g. running jobs whose worker died
+	HeartbeatBefore *time.Time
+	// Jobs must have all the given tags
+	Tags []string
+	// Jobs must have all the given metadata key/value pairs
+	Metadata map[string]string
 	pagination.Params
 }
+
+type PurgeCriteria struct {
+	// Only jobs with one of these statuses are purged
+	Statuses []Status
+	// Retention of the accounts without their own. 0 disables it
+	DefaultRetentionDays int
+	// Jobs are past their retention when they finished (or were created, if they
+	// never ran) more than the retention days before this time
+	Now   time.Time
+	Limit int
+	// Whether the purged jobs are copied to the archive table
+	ArchiveToTable bool
+}
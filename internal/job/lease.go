@@ -0,0 +1,78 @@
+package job
+
+import "time"
+
+const (
+	DefaultLeaseSec = 60
+	MaxLeaseSec     = 3600
+
+	DefaultLeaseLimit = 10
+	MaxLeaseLimit     = 100
+	// how many tasks a lease request can ask jobs of
+	MaxLeaseTasks = 20
+)
+
+// LeaseRequest asks for the due external jobs of the given tasks, which are
+// handed to the caller until the lease expires.
+type LeaseRequest struct {
+	// Names of the external tasks, see [task.ExternalPayload]
+	Tasks []string `json:"tasks"`
+	// How many jobs at most, DefaultLeaseLimit if not set
+	Limit *int `json:"limit"`
+	// Seconds the jobs are leased for, DefaultLeaseSec if not set. The lease
+	// is extended by each heartbeat
+	LeaseSec *int `json:"lease_sec"`
+}
+
+// Lease is the jobs handed to an external consumer by a lease request. Its id
+// must be given to report their outcome.
+type Lease struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Jobs      []*Job    `json:"jobs"`
+}
+
+// LeaseCriteria selects the jobs leased by a lease request.
+type LeaseCriteria struct {
+	AccountID string
+	Tasks     []string
+	Limit     int
+	LeaseID   string
+	Now       time.Time
+	ExpiresAt time.Time
+}
+
+type LeaseHeartbeatRequest struct {
+	LeaseID string `json:"lease_id"`
+	// Seconds the lease is extended for from now, DefaultLeaseSec if not set
+	LeaseSec *int `json:"lease_sec"`
+	// Reported along with the heartbeat, if set
+	Progress *ProgressRequest `json:"progress"`
+}
+
+type ProgressRequest struct {
+	Percent int    `json:"percent"`
+	Message string `json:"message"`
+}
+
+type LeaseCompleteRequest struct {
+	LeaseID string `json:"lease_id"`
+}
+
+// LeaseFailRequest reports a failed attempt of a leased job, which is retried
+// like the attempts run by the worker.
+type LeaseFailRequest struct {
+	LeaseID string `json:"lease_id"`
+	Error   string `json:"error"`
+	// Classification of the failure stored on the job
+	Code string `json:"code"`
+	// The job fails right away, without using its remaining retries
+	Permanent bool `json:"permanent"`
+	// Minimum seconds to wait before the next attempt
+	RetryAfterSec *int `json:"retry_after_sec"`
+}
+
+const (
+	MaxLeaseErrorLength     = 1024
+	MaxLeaseErrorCodeLength = 64
+)
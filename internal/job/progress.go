@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+const MaxProgressMessageLength = 256
+
+// Progress is the last progress reported by the executor of a running job.
+type Progress struct {
+	Percent   int       `json:"percent"`
+	Message   string    `json:"message,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProgressReporter persists the progress of the job being executed.
+type ProgressReporter func(ctx context.Context, percent int, message string) error
+
+type contextKey string
+
+const progressReporterContextKey = contextKey("progressReporter")
+
+// ContextSetProgressReporter returns a context that executors use to report the
+// progress of the job, see [ReportProgress].
+func ContextSetProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterContextKey, reporter)
+}
+
+// ReportProgress reports the progress of the job executed with the given context,
+// percent must be between 0 and 100. It does nothing if the context has no reporter.
+func ReportProgress(ctx context.Context, percent int, message string) error {
+	reporter, ok := ctx.Value(progressReporterContextKey).(ProgressReporter)
+	if !ok {
+		return nil
+	}
+
+	return reporter(ctx, percent, message)
+}
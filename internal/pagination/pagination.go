@@ -1,19 +1,124 @@
 package pagination
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ngmmartins/asyncq/internal/validator"
 )
 
-var JobSortSafelist = []string{"id", "task", "run_at", "status", "created_at", "-id", "-task", "-run_at", "-status", "-created_at"}
+var JobSortSafelist = []string{"id", "task", "run_at", "status", "created_at", "finished_at", "retries",
+	"-id", "-task", "-run_at", "-status", "-created_at", "-finished_at", "-retries"}
+
+// JobSortColumnTypes are the SQL types of the columns jobs can be sorted by,
+// which the values of the cursors must be valid for.
+var JobSortColumnTypes = map[string]string{
+	"id":          "uuid",
+	"task":        "text",
+	"status":      "text",
+	"run_at":      "timestamptz",
+	"created_at":  "timestamptz",
+	"finished_at": "timestamptz",
+	"retries":     "integer",
+}
+
+// the layouts of a timestamptz as text, the offset depending on the session time zone
+var timestamptzLayouts = []string{
+	"2006-01-02 15:04:05.999999Z07",
+	"2006-01-02 15:04:05.999999Z07:00",
+	"2006-01-02 15:04:05.999999Z07:00:00",
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
 
 type Params struct {
 	Page         int
 	PageSize     int
 	SortBy       string
 	SortSafelist []string
+	// When set, keyset pagination is used instead of Page.
+	// An empty Cursor requests the first page.
+	Cursor *Cursor
+}
+
+// Cursor points to the last record of a page when using keyset pagination.
+// Clients see it as an opaque string, see [Cursor.Encode].
+type Cursor struct {
+	SortBy string `json:"s"`
+	// The value of the sort column of the record, as text
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by [Cursor.Encode].
+// An empty string decodes to the cursor of the first page.
+func DecodeCursor(s string) (*Cursor, error) {
+	var c Cursor
+
+	if s == "" {
+		return &c, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.IsFirst() && !c.valid() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// valid reports whether the ID and the value of the cursor can be compared
+// with the rows, so a tampered cursor isn't sent to the store.
+func (c *Cursor) valid() bool {
+	if uuid.Validate(c.ID) != nil || !slices.Contains(JobSortSafelist, c.SortBy) {
+		return false
+	}
+
+	switch JobSortColumnTypes[strings.TrimPrefix(c.SortBy, "-")] {
+	case "uuid":
+		return uuid.Validate(c.Value) == nil
+	case "integer":
+		_, err := strconv.ParseInt(c.Value, 10, 32)
+		return err == nil
+	case "timestamptz":
+		// NULLs are sorted as infinity, see the keyset search of the store
+		if c.Value == "infinity" {
+			return true
+		}
+		for _, layout := range timestamptzLayouts {
+			if _, err := time.Parse(layout, c.Value); err == nil {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// IsFirst reports whether the cursor requests the first page.
+func (c *Cursor) IsFirst() bool {
+	return c.ID == ""
 }
 
 func (p Params) SortColumn() string {
@@ -47,16 +152,27 @@ func Validate(v *validator.Validator, p *Params, applyDefaults bool) {
 		if p.PageSize == 0 {
 			p.PageSize = 20
 		}
-		if p.SortBy == "" {
-			p.SortBy = "-created_at"
-		}
-		p.SortSafelist = JobSortSafelist
 	}
 
 	v.Check(p.Page > 0, "page", "must be greater than zero")
 	v.Check(p.Page <= 10_000_000, "page", "must be a maximum of 10 million")
 	v.Check(p.PageSize > 0, "page_size", "must be greater than zero")
 	v.Check(p.PageSize <= 100, "page_size", "must be a maximum of 100")
+	ValidateSort(v, p, applyDefaults)
+	if p.Cursor != nil && !p.Cursor.IsFirst() {
+		v.Check(p.Cursor.SortBy == p.SortBy, "cursor", "was created for a different sort value")
+	}
+}
+
+// ValidateSort only validates the sort parameters, for reads that aren't paginated.
+func ValidateSort(v *validator.Validator, p *Params, applyDefaults bool) {
+	if applyDefaults {
+		if p.SortBy == "" {
+			p.SortBy = "-created_at"
+		}
+		p.SortSafelist = JobSortSafelist
+	}
+
 	v.Check(slices.Contains(p.SortSafelist, p.SortBy), "sortBy", "invalid sort value")
 }
 
@@ -66,6 +182,8 @@ type Metadata struct {
 	FirstPage    int `json:"first_page,omitzero"`
 	LastPage     int `json:"last_page,omitzero"`
 	TotalRecords int `json:"total_records,omitzero"`
+	// Only set when using keyset pagination and there are more records
+	NextCursor string `json:"next_cursor,omitzero"`
 }
 
 func NewMetadata(totalRecords, page, pageSize int) *Metadata {
@@ -81,3 +199,15 @@ func NewMetadata(totalRecords, page, pageSize int) *Metadata {
 		TotalRecords: totalRecords,
 	}
 }
+
+// NewCursorMetadata returns the metadata of a page read with keyset pagination.
+// The next cursor is only set when there are more records after the given one.
+func NewCursorMetadata(pageSize int, next *Cursor) *Metadata {
+	metadata := &Metadata{PageSize: pageSize}
+
+	if next != nil {
+		metadata.NextCursor = next.Encode()
+	}
+
+	return metadata
+}
@@ -0,0 +1,77 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// KeySize is the size of the key secrets are encrypted with, in bytes
+const KeySize = 32
+
+var (
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+	ErrNoKey             = errors.New("no secrets key configured")
+)
+
+// Cipher encrypts secrets with AES-256-GCM. Deployments without a secrets key
+// have a nil Cipher, which can't encrypt secrets.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher returns a cipher using the base64 encoded key.
+func NewCipher(encodedKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding secrets key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secrets key must be %d bytes long, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt returns the plaintext encrypted, prefixed by the random nonce used.
+func (c *Cipher) Encrypt(plaintext []byte) []byte {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	// crypto/rand.Read never returns an error
+	rand.Read(nonce)
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil)
+}
+
+// Decrypt returns the plaintext of a secret encrypted with Encrypt, or
+// ErrNoKey if the cipher is nil.
+func (c *Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if c == nil {
+		return nil, ErrNoKey
+	}
+
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():]
+
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNameLength  = 64
+	MaxValueLength = 16 * 1024
+)
+
+var NameRX = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Secret is a credential stored for an account, e.g. the token of a webhook
+// target. Payloads reference secrets by name so the credentials are never part
+// of a job.
+type Secret struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	// Encrypted value, the plaintext is never returned by the API
+	Value     []byte    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func New(accountId, name string, value []byte) *Secret {
+	now := time.Now()
+
+	return &Secret{
+		ID:        uuid.NewString(),
+		AccountID: accountId,
+		Name:      name,
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type PutRequest struct {
+	Value string `json:"value"`
+}
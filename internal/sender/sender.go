@@ -0,0 +1,144 @@
+package sender
+
+import (
+	"crypto/rand"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxAddressLength  = 254
+	MaxHostLength     = 253
+	MaxUsernameLength = 255
+	MaxPasswordLength = 1024
+
+	// verificationRecordPrefix is prepended to the domain of an identity to get
+	// the name of the TXT record that verifies it
+	verificationRecordPrefix = "_asyncq-verification."
+	verificationTokenPrefix  = "asyncq-verification="
+)
+
+var (
+	// HostRX matches hostnames, including single label ones such as localhost
+	HostRX   = regexp.MustCompile(`^(?i)[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)*$`)
+	DomainRX = regexp.MustCompile(`^(?i)(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+)
+
+// ValidAddress reports whether the address is a plain email address, without a
+// display name, or a domain.
+func ValidAddress(address string) bool {
+	if strings.Contains(address, "@") {
+		parsed, err := mail.ParseAddress(address)
+		return err == nil && parsed.Address == address && DomainRX.MatchString(address[strings.LastIndex(address, "@")+1:])
+	}
+	return DomainRX.MatchString(address)
+}
+
+// Identity is an email address, or a whole domain, an account can send emails
+// from. It's verified by publishing its verification token in a TXT record of
+// the domain, proving the account controls it.
+type Identity struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	// An email address, or a domain to allow all its addresses
+	Address           string     `json:"address"`
+	VerificationToken string     `json:"verification_token"`
+	VerifiedAt        *time.Time `json:"verified_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func NewIdentity(accountId, address string) *Identity {
+	return &Identity{
+		ID:                uuid.NewString(),
+		AccountID:         accountId,
+		Address:           strings.ToLower(address),
+		VerificationToken: verificationTokenPrefix + rand.Text(),
+		CreatedAt:         time.Now(),
+	}
+}
+
+func (i *Identity) Verified() bool {
+	return i.VerifiedAt != nil
+}
+
+func (i *Identity) IsDomain() bool {
+	return !strings.Contains(i.Address, "@")
+}
+
+// Domain returns the domain of the identity, which holds its verification record.
+func (i *Identity) Domain() string {
+	_, domain, found := strings.Cut(i.Address, "@")
+	if !found {
+		return i.Address
+	}
+	return domain
+}
+
+// VerificationRecord returns the name of the TXT record the verification token
+// must be published in.
+func (i *Identity) VerificationRecord() string {
+	return verificationRecordPrefix + i.Domain()
+}
+
+// Covers reports whether the identity allows sending from the email address.
+func (i *Identity) Covers(address string) bool {
+	address = strings.ToLower(address)
+
+	if i.IsDomain() {
+		_, domain, found := strings.Cut(address, "@")
+		return found && domain == i.Address
+	}
+	return address == i.Address
+}
+
+// CoveredBy reports whether any of the verified identities allows sending from the email address.
+func CoveredBy(identities []*Identity, address string) bool {
+	for _, identity := range identities {
+		if identity.Verified() && identity.Covers(address) {
+			return true
+		}
+	}
+	return false
+}
+
+type IdentityRequest struct {
+	Address string `json:"address"`
+}
+
+// SMTPConfig is the SMTP server the emails of an account are sent through,
+// instead of the one the worker is configured with.
+type SMTPConfig struct {
+	AccountID string `json:"account_id"`
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	Username  string `json:"username,omitempty"`
+	// Encrypted password, the plaintext is never returned by the API
+	Password  []byte    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewSMTPConfig(accountId string, request *SMTPConfigRequest, password []byte) *SMTPConfig {
+	now := time.Now()
+
+	return &SMTPConfig{
+		AccountID: accountId,
+		Host:      request.Host,
+		Port:      request.Port,
+		Username:  request.Username,
+		Password:  password,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type SMTPConfigRequest struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
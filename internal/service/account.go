@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/ngmmartins/asyncq/internal/account"
 	"github.com/ngmmartins/asyncq/internal/store"
 	"github.com/ngmmartins/asyncq/internal/token"
+	"github.com/ngmmartins/asyncq/internal/validator"
 )
 
 type AccountService struct {
@@ -46,3 +48,28 @@ func (s *AccountService) GetForToken(ctx context.Context, plaintext string, scop
 
 	return acc, nil
 }
+
+func (s *AccountService) UpdateJobRetention(ctx context.Context, accountId string, request *account.UpdateJobRetentionRequest) error {
+	v := validator.New()
+	s.validateUpdateJobRetention(v, request)
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	err := s.store.Account().UpdateJobRetention(ctx, accountId, request.JobRetentionDays)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *AccountService) validateUpdateJobRetention(v *validator.Validator, request *account.UpdateJobRetentionRequest) {
+	days := request.JobRetentionDays
+	v.Check(days == nil || *days >= 0, "job_retention_days", "if set must be equal or greater than 0")
+	v.Check(days == nil || *days <= account.MaxJobRetentionDays, "job_retention_days",
+		fmt.Sprintf("must be a maximum of %d", account.MaxJobRetentionDays))
+}
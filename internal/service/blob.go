@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"strings"
+
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+var ErrBlobTooLarge = fmt.Errorf("blob must not be more than %d bytes long", blob.MaxSize)
+
+type BlobService struct {
+	logger   *slog.Logger
+	store    store.Store
+	contents blob.Store
+}
+
+func NewBlobService(logger *slog.Logger, store store.Store, contents blob.Store) *BlobService {
+	return &BlobService{logger: logger, store: store, contents: contents}
+}
+
+// UploadBlob stores the content read from r as a new blob of the account.
+// It returns ErrBlobTooLarge if the content is larger than [blob.MaxSize].
+func (s *BlobService) UploadBlob(ctx context.Context, accountId, filename, contentType string, r io.Reader) (*blob.Blob, error) {
+	if contentType == "" {
+		contentType = blob.DefaultContentType
+	}
+
+	v := validator.New()
+	s.validateUploadBlob(v, filename, contentType)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	b := blob.New(accountId, filename, contentType)
+
+	// reading one byte past the limit tells a blob of the max size from a larger one
+	size, err := s.contents.Put(ctx, b.ID, io.LimitReader(r, blob.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 || size > blob.MaxSize {
+		s.deleteContent(ctx, b.ID)
+		if size == 0 {
+			return nil, &validator.ValidationError{Errors: map[string]string{"content": "must not be empty"}}
+		}
+		return nil, ErrBlobTooLarge
+	}
+	b.Size = size
+
+	err = s.store.Blob().Save(ctx, b)
+	if err != nil {
+		s.logger.Error("failed to store blob", "id", b.ID, "err", err.Error())
+		s.deleteContent(ctx, b.ID)
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *BlobService) GetBlob(ctx context.Context, id, accountId string) (*blob.Blob, error) {
+	b, err := s.store.Blob().Get(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ReadBlob returns the blob of the account along with its content.
+func (s *BlobService) ReadBlob(ctx context.Context, id, accountId string) (*blob.Blob, []byte, error) {
+	b, err := s.GetBlob(ctx, id, accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.contents.Open(ctx, id)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, ErrRecordNotFound
+		}
+		return nil, nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, content, nil
+}
+
+// DeleteBlob deletes the blob of the account. Jobs still referencing it fail when executed.
+func (s *BlobService) DeleteBlob(ctx context.Context, id, accountId string) error {
+	err := s.store.Blob().Delete(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	s.deleteContent(ctx, id)
+
+	return nil
+}
+
+// deleteContent deletes the content of a blob. Errors are only logged, as the
+// content is unreachable without the metadata anyway.
+func (s *BlobService) deleteContent(ctx context.Context, id string) {
+	err := s.contents.Delete(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to delete blob content", "id", id, "err", err.Error())
+	}
+}
+
+func (s *BlobService) validateUploadBlob(v *validator.Validator, filename, contentType string) {
+	v.CheckRequired(filename != "", "filename")
+	v.Check(len(filename) <= blob.MaxFilenameLength, "filename", fmt.Sprintf("must not be more than %d bytes long", blob.MaxFilenameLength))
+	v.Check(!strings.ContainsAny(filename, "/\\\r\n"), "filename", "must not contain path separators or line breaks")
+
+	_, _, err := mime.ParseMediaType(contentType)
+	v.Check(err == nil, "content_type", "must be a valid media type")
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/emailtemplate"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+type EmailTemplateService struct {
+	logger *slog.Logger
+	store  store.Store
+}
+
+func NewEmailTemplateService(logger *slog.Logger, store store.Store) *EmailTemplateService {
+	return &EmailTemplateService{logger: logger, store: store}
+}
+
+func (s *EmailTemplateService) CreateEmailTemplate(ctx context.Context, accountId string, request *emailtemplate.Request) (*emailtemplate.Template, error) {
+	template := emailtemplate.New(accountId, request)
+
+	v := validator.New()
+	s.validateEmailTemplate(v, template)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	err := s.store.EmailTemplate().Save(ctx, template)
+	if err != nil {
+		if errors.Is(err, store.ErrDuplicateRecord) {
+			return nil, duplicateTemplateNameError()
+		}
+		s.logger.Error("failed to store email template", "err", err.Error())
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *EmailTemplateService) GetEmailTemplates(ctx context.Context, accountId string) ([]*emailtemplate.Template, error) {
+	return s.store.EmailTemplate().GetByAccountId(ctx, accountId)
+}
+
+func (s *EmailTemplateService) GetEmailTemplate(ctx context.Context, id, accountId string) (*emailtemplate.Template, error) {
+	template, err := s.store.EmailTemplate().Get(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// UpdateEmailTemplate replaces all the fields of the template. Jobs already
+// created with it keep the content it was rendered with.
+func (s *EmailTemplateService) UpdateEmailTemplate(ctx context.Context, id, accountId string, request *emailtemplate.Request) (*emailtemplate.Template, error) {
+	template, err := s.GetEmailTemplate(ctx, id, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = request.Name
+	template.Subject = request.Subject
+	template.TextBody = request.TextBody
+	template.HTMLBody = request.HTMLBody
+	template.UpdatedAt = time.Now()
+
+	v := validator.New()
+	s.validateEmailTemplate(v, template)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	err = s.store.EmailTemplate().Update(ctx, template)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrDuplicateRecord):
+			return nil, duplicateTemplateNameError()
+		case errors.Is(err, store.ErrNoRowsAffected):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
+
+func (s *EmailTemplateService) DeleteEmailTemplate(ctx context.Context, id, accountId string) error {
+	err := s.store.EmailTemplate().Delete(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *EmailTemplateService) validateEmailTemplate(v *validator.Validator, template *emailtemplate.Template) {
+	v.CheckRequired(template.Name != "", "name")
+	v.Check(len(template.Name) <= emailtemplate.MaxNameLength, "name", fmt.Sprintf("must not be more than %d bytes long", emailtemplate.MaxNameLength))
+	v.CheckRequired(template.Subject != "", "subject")
+	v.Check(len(template.Subject) <= emailtemplate.MaxSubjectLength, "subject", fmt.Sprintf("must not be more than %d bytes long", emailtemplate.MaxSubjectLength))
+	v.Check(template.TextBody != "" || template.HTMLBody != "", "text_body", "text_body or html_body is required")
+	v.Check(len(template.TextBody) <= emailtemplate.MaxBodyLength, "text_body", fmt.Sprintf("must not be more than %d bytes long", emailtemplate.MaxBodyLength))
+	v.Check(len(template.HTMLBody) <= emailtemplate.MaxBodyLength, "html_body", fmt.Sprintf("must not be more than %d bytes long", emailtemplate.MaxBodyLength))
+
+	if !v.Valid() {
+		return
+	}
+
+	var templateError *emailtemplate.Error
+	if err := template.Validate(); errors.As(err, &templateError) {
+		v.AddError(templateError.Field, templateError.Err.Error())
+	}
+}
+
+func duplicateTemplateNameError() error {
+	return &validator.ValidationError{Errors: map[string]string{"name": "a template with this name already exists"}}
+}
@@ -2,38 +2,64 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/mail"
 	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ngmmartins/asyncq/internal/event"
 	"github.com/ngmmartins/asyncq/internal/job"
 	"github.com/ngmmartins/asyncq/internal/pagination"
 	"github.com/ngmmartins/asyncq/internal/queue"
+	"github.com/ngmmartins/asyncq/internal/sender"
 	"github.com/ngmmartins/asyncq/internal/store"
 	"github.com/ngmmartins/asyncq/internal/task"
 	"github.com/ngmmartins/asyncq/internal/validator"
+	"github.com/ngmmartins/asyncq/registry"
 )
 
 type JobService struct {
 	logger *slog.Logger
 	queue  queue.Queue
 	store  store.Store
+	broker event.Broker
+	// false without a secrets key, webhook auth credentials are account secrets
+	secretsEnabled bool
 }
 
-func NewJobService(logger *slog.Logger, queue queue.Queue, store store.Store) *JobService {
-	return &JobService{logger: logger, queue: queue, store: store}
+func NewJobService(logger *slog.Logger, queue queue.Queue, store store.Store, broker event.Broker, secretsEnabled bool) *JobService {
+	return &JobService{logger: logger, queue: queue, store: store, broker: broker, secretsEnabled: secretsEnabled}
 }
 
-func (s *JobService) CreateJob(ctx context.Context, request *job.CreateRequest) (*job.Job, error) {
+func (s *JobService) CreateJob(ctx context.Context, accountId string, request *job.CreateRequest) (*job.Job, error) {
+	// a retried request returns the job it created, even if it wouldn't be valid anymore, e.g. its run_at passed
+	if request.IdempotencyKey != "" && len(request.IdempotencyKey) <= job.MaxIdempotencyKeyLength {
+		j, err := s.store.Job().GetByIdempotencyKey(ctx, accountId, request.IdempotencyKey)
+		if err == nil {
+			return j, nil
+		}
+		if !errors.Is(err, store.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
 	v := validator.New()
 	s.validateCreateJob(v, request)
 	if !v.Valid() {
 		return nil, &validator.ValidationError{Errors: v.Errors}
 	}
 
+	if request.Task == task.SendEmailTask {
+		err := s.prepareEmailPayload(ctx, accountId, request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	now := time.Now()
 
 	var status job.Status
@@ -54,8 +80,19 @@ func (s *JobService) CreateJob(ctx context.Context, request *job.CreateRequest)
 		retryDelay = *request.RetryDelaySec
 	}
 
+	// stored as empty values so they always match the containment operators used on search
+	tags := request.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	metadata := request.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
 	job := job.Job{
 		ID:            uuid.NewString(),
+		AccountID:     accountId,
 		Task:          request.Task,
 		Payload:       request.Payload,
 		RunAt:         request.RunAt,
@@ -63,14 +100,26 @@ func (s *JobService) CreateJob(ctx context.Context, request *job.CreateRequest)
 		CreatedAt:     now,
 		MaxRetries:    maxRetries,
 		RetryDelaySec: retryDelay,
+		Tags:          tags,
+		Metadata:      metadata,
+		ExpiresAt:     request.ExpiresAt,
+	}
+	if request.IdempotencyKey != "" {
+		job.IdempotencyKey = &request.IdempotencyKey
 	}
 
 	err := s.store.Job().Save(ctx, &job)
 	if err != nil {
+		// a concurrent request with the same key created the job first
+		if errors.Is(err, store.ErrDuplicateRecord) && job.IdempotencyKey != nil {
+			return s.store.Job().GetByIdempotencyKey(ctx, accountId, *job.IdempotencyKey)
+		}
 		s.logger.Error("failed to store job", "id", job.ID, "err", err.Error())
 		return nil, err
 	}
 
+	s.recordStatusChange(ctx, &job, "")
+
 	if job.RunAt != nil {
 		err = s.queue.Enqueue(ctx, job.ID, *job.RunAt)
 		if err != nil {
@@ -82,6 +131,100 @@ func (s *JobService) CreateJob(ctx context.Context, request *job.CreateRequest)
 	return &job, nil
 }
 
+// prepareEmailPayload checks the sender of a send_email payload is a verified
+// identity of the account and its attached blobs exist, then renders its
+// template, if any. They all return a validation error when they fail.
+func (s *JobService) prepareEmailPayload(ctx context.Context, accountId string, request *job.CreateRequest) error {
+	decoded, err := registry.DecodePayload(request.Task, request.Payload)
+	if err != nil {
+		return err
+	}
+
+	payload := decoded.(task.SendEmailPayload)
+
+	err = s.checkEmailSender(ctx, accountId, payload.From)
+	if err != nil {
+		return err
+	}
+
+	for i, a := range payload.Attachments {
+		if a.BlobID == "" {
+			continue
+		}
+
+		_, err := s.store.Blob().Get(ctx, a.BlobID, accountId)
+		if err != nil {
+			if errors.Is(err, store.ErrRecordNotFound) {
+				return &validator.ValidationError{Errors: map[string]string{fmt.Sprintf("payload.attachments[%d].blob_id", i): "blob not found"}}
+			}
+			return err
+		}
+	}
+
+	if payload.TemplateID == "" {
+		return nil
+	}
+
+	err = s.renderEmailTemplate(ctx, accountId, &payload)
+	if err != nil {
+		return err
+	}
+
+	request.Payload, err = json.Marshal(payload)
+	return err
+}
+
+// checkEmailSender returns a validation error unless the from address is covered
+// by a verified sender identity of the account.
+func (s *JobService) checkEmailSender(ctx context.Context, accountId, from string) error {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return &validator.ValidationError{Errors: map[string]string{"payload.from": "must be a valid email address"}}
+	}
+
+	identities, err := s.store.SenderIdentity().GetByAccountId(ctx, accountId)
+	if err != nil {
+		return err
+	}
+
+	if !sender.CoveredBy(identities, address.Address) {
+		return &validator.ValidationError{Errors: map[string]string{"payload.from": "must be a verified sender identity of the account"}}
+	}
+
+	return nil
+}
+
+// renderEmailTemplate renders the template of a send_email payload into its subject
+// and bodies, so the email sent doesn't change if the template is edited later.
+// Unknown templates and data that fails to render return a validation error.
+func (s *JobService) renderEmailTemplate(ctx context.Context, accountId string, payload *task.SendEmailPayload) error {
+	template, err := s.store.EmailTemplate().Get(ctx, payload.TemplateID, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return &validator.ValidationError{Errors: map[string]string{"payload.template_id": "template not found"}}
+		}
+		return err
+	}
+
+	rendered, err := template.Render(payload.Data)
+	if err != nil {
+		return &validator.ValidationError{Errors: map[string]string{"payload.data": fmt.Sprintf("failed to render template: %s", err)}}
+	}
+
+	payload.Subject = rendered.Subject
+	payload.Body = rendered.TextBody
+	payload.HTMLBody = rendered.HTMLBody
+
+	// the data may render a subject or bodies that a plain email couldn't have
+	v := validator.New()
+	task.ValidateEmailContent(v, payload)
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	return nil
+}
+
 func (s *JobService) SearchJobs(ctx context.Context, criteria *job.SearchCriteria) ([]*job.Job, *pagination.Metadata, error) {
 	v := validator.New()
 	s.validateSearchJobs(v, criteria)
@@ -97,6 +240,24 @@ func (s *JobService) SearchJobs(ctx context.Context, criteria *job.SearchCriteri
 	return jobs, metadata, nil
 }
 
+// ExportJobs calls fn for every job matching the criteria, without paginating them.
+// Jobs are read from the store in batches as fn consumes them, so the whole
+// result is never held in memory. It stops at the first error returned by fn.
+func (s *JobService) ExportJobs(ctx context.Context, criteria *job.SearchCriteria, fn func(*job.Job) error) error {
+	v := validator.New()
+	s.validateExportJobs(v, criteria)
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	return s.store.Job().Export(ctx, criteria, fn)
+}
+
+// PurgeJobs deletes a batch of jobs past their retention, see [store.JobStore].Purge.
+func (s *JobService) PurgeJobs(ctx context.Context, criteria *job.PurgeCriteria, archive func([]*job.Job) error) (int, error) {
+	return s.store.Job().Purge(ctx, criteria, archive)
+}
+
 func (s *JobService) GetJob(ctx context.Context, jobId string) (*job.Job, error) {
 	j, err := s.store.Job().Get(ctx, jobId)
 	if err != nil {
@@ -109,12 +270,24 @@ func (s *JobService) GetJob(ctx context.Context, jobId string) (*job.Job, error)
 	return j, nil
 }
 
-func (s *JobService) ScheduleJob(ctx context.Context, jobId string, runAt time.Time) error {
-	j, err := s.store.Job().Get(ctx, jobId)
+// GetAccountJob returns the job if it belongs to the account, the jobs of other
+// accounts aren't found.
+func (s *JobService) GetAccountJob(ctx context.Context, accountId, jobId string) (*job.Job, error) {
+	j, err := s.GetJob(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.AccountID != accountId {
+		return nil, ErrRecordNotFound
+	}
+
+	return j, nil
+}
+
+func (s *JobService) ScheduleJob(ctx context.Context, accountId, jobId string, runAt time.Time) error {
+	j, err := s.GetAccountJob(ctx, accountId, jobId)
 	if err != nil {
-		if errors.Is(err, store.ErrRecordNotFound) {
-			return ErrRecordNotFound
-		}
 		return err
 	}
 
@@ -122,6 +295,11 @@ func (s *JobService) ScheduleJob(ctx context.Context, jobId string, runAt time.T
 		return fmt.Errorf("%w from %q to %q", ErrInvalidStatusTransition, j.Status, job.StatusQueued)
 	}
 
+	if j.ExpiresAt != nil && !runAt.Before(*j.ExpiresAt) {
+		return &validator.ValidationError{Errors: map[string]string{"run_at": "must be before the job expires_at"}}
+	}
+
+	previousStatus := j.Status
 	j.RunAt = &runAt
 	j.Status = job.StatusQueued
 
@@ -130,6 +308,8 @@ func (s *JobService) ScheduleJob(ctx context.Context, jobId string, runAt time.T
 		return err
 	}
 
+	s.recordStatusChange(ctx, j, previousStatus)
+
 	err = s.queue.Enqueue(ctx, j.ID, *j.RunAt)
 	if err != nil {
 		return err
@@ -147,6 +327,8 @@ func (s *JobService) UpdateJobFields(ctx context.Context, jobId string, fields *
 		return err
 	}
 
+	previousStatus := j.Status
+
 	if fields.SetRunAt {
 		j.RunAt = fields.RunAt
 	}
@@ -162,50 +344,385 @@ func (s *JobService) UpdateJobFields(ctx context.Context, jobId string, fields *
 	}
 	if fields.SetLastError {
 		j.LastError = fields.LastError
+		j.LastErrorCode = fields.LastErrorCode
+	}
+	if fields.ClearLease {
+		j.LeaseID = nil
+		j.LeaseExpiresAt = nil
 	}
 
-	return s.store.Job().Update(ctx, j)
+	if fields.LeaseID != nil {
+		err = s.store.Job().UpdateLeased(ctx, j, *fields.LeaseID, fields.LeaseExpiredBefore)
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrLeaseLost
+		}
+	} else {
+		err = s.store.Job().Update(ctx, j)
+	}
+	if err != nil {
+		return err
+	}
 
+	if j.Status != previousStatus {
+		s.recordStatusChange(ctx, j, previousStatus)
+	}
+
+	return nil
 }
 
-func (s *JobService) UpdateJobStatus(ctx context.Context, jobId string, newStatus job.Status) error {
-	j, err := s.store.Job().Get(ctx, jobId)
+// ExpireJob finishes the job as expired without running it, as long as it's
+// still queued, so a job cancelled meanwhile isn't expired. Otherwise an
+// ErrInvalidStatusTransition error is returned.
+func (s *JobService) ExpireJob(ctx context.Context, jobId string) error {
+	j, err := s.GetJob(ctx, jobId)
 	if err != nil {
-		if errors.Is(err, store.ErrRecordNotFound) {
-			return ErrRecordNotFound
+		return err
+	}
+
+	if j.Status != job.StatusQueued {
+		return fmt.Errorf("%w from %q to %q", ErrInvalidStatusTransition, j.Status, job.StatusExpired)
+	}
+
+	now := time.Now()
+	j.Status = job.StatusExpired
+	j.FinishedAt = &now
+
+	err = s.store.Job().UpdateFromStatus(ctx, j, job.StatusQueued)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return fmt.Errorf("%w from a status other than %q to %q", ErrInvalidStatusTransition, job.StatusQueued, job.StatusExpired)
+		}
+		return err
+	}
+
+	s.recordStatusChange(ctx, j, job.StatusQueued)
+
+	return nil
+}
+
+// FinishJobAttempt records the outcome of an attempt of the running job, given
+// the error it failed with, if any. Failed jobs are queued again while they have
+// retries left, unless the error is permanent, see [task.ExecutionError], or the
+// next attempt would be after the job expires.
+func (s *JobService) FinishJobAttempt(ctx context.Context, j *job.Job, err error) error {
+	return s.finishJobAttempt(ctx, j, err, nil)
+}
+
+// finishJobAttempt is [JobService.FinishJobAttempt], which for a leased job
+// returns ErrLeaseLost if the attempt was already finished, or if the lease
+// didn't expire before leaseExpiredBefore, when given.
+func (s *JobService) finishJobAttempt(ctx context.Context, j *job.Job, err error, leaseExpiredBefore *time.Time) error {
+	now := time.Now()
+	updateFields := job.UpdateFields{}
+
+	updateFields.SetFinishedAt = true
+	updateFields.FinishedAt = &now
+
+	// leased external jobs are done with, whatever the outcome, but only once:
+	// a completion can race the expiry of the lease or be reported twice
+	updateFields.ClearLease = true
+	updateFields.LeaseID = j.LeaseID
+	updateFields.LeaseExpiredBefore = leaseExpiredBefore
+
+	if err != nil {
+		s.logger.Debug("job execution failed", "jobId", j.ID, "err", err.Error())
+		updateFields.SetLastError = true
+		lastErr := err.Error()
+		updateFields.LastError = &lastErr
+
+		// executors can tell a retry won't help, or ask for a longer delay
+		var execErr *task.ExecutionError
+		errors.As(err, &execErr)
+
+		if execErr != nil && execErr.Code != "" {
+			updateFields.LastErrorCode = &execErr.Code
+		}
+
+		retryDelay := time.Second * time.Duration(j.RetryDelaySec)
+		if execErr != nil {
+			retryDelay = max(retryDelay, execErr.RetryAfter)
+		}
+
+		enqueueJob := false
+		nextRunAt := now.Add(retryDelay)
+		// Check if the job still has retry attempts left
+		if errors.Is(err, task.ErrSuppressed) {
+			s.logger.Debug("job targets a suppressed address", "jobId", j.ID)
+			updateFields.SetStatus = true
+			status := job.StatusSuppressed
+			updateFields.Status = &status
+
+		} else if execErr != nil && execErr.Permanent {
+			s.logger.Debug("job failed permanently, skipping remaining attempts", "jobId", j.ID, "retries", j.Retries, "maxRetries", j.MaxRetries)
+			updateFields.SetStatus = true
+			status := job.StatusFailed
+			updateFields.Status = &status
+
+		} else if j.Retries < j.MaxRetries && j.ExpiresAt != nil && !nextRunAt.Before(*j.ExpiresAt) {
+			s.logger.Debug("job next attempt would be after it expires", "jobId", j.ID, "nextRunAt", nextRunAt, "expiresAt", j.ExpiresAt)
+			updateFields.SetStatus = true
+			status := job.StatusExpired
+			updateFields.Status = &status
+
+		} else if j.Retries < j.MaxRetries {
+			s.logger.Debug("job still has remaining attempts", "jobId", j.ID, "retries", j.Retries, "maxRetries", j.MaxRetries)
+			enqueueJob = true
+
+			updateFields.SetRetries = true
+			newRetries := j.Retries + 1
+			updateFields.Retries = &newRetries
+
+			updateFields.SetStatus = true
+			status := job.StatusQueued
+			updateFields.Status = &status
+
+			updateFields.SetRunAt = true
+			updateFields.RunAt = &nextRunAt
+
+		} else {
+			s.logger.Debug("job does not have remaining attempts", "jobId", j.ID, "retries", j.Retries, "maxRetries", j.MaxRetries)
+			updateFields.SetStatus = true
+			status := job.StatusFailed
+			updateFields.Status = &status
+		}
+
+		s.logger.Debug("updating job fields", "jobId", j.ID, "updateFields", updateFields)
+		err = s.UpdateJobFields(ctx, j.ID, &updateFields)
+		if err != nil {
+			return fmt.Errorf("updating job fields: %w", err)
+		}
+
+		if enqueueJob {
+			s.logger.Debug("Enqueueing job again with new RunAt", "jobId", j.ID, "RunAt", updateFields.RunAt)
+			// Enqueue the job again to be retried
+			err := s.queue.Enqueue(ctx, j.ID, *updateFields.RunAt)
+			if err != nil {
+				s.logger.Error("failed to enqueue job", "jobID", j.ID)
+			}
 		}
+
+		return nil
+	}
+
+	s.logger.Debug("job execution succedded", "jobId", j.ID)
+
+	// Clear eventual past errors
+	updateFields.SetLastError = true
+	updateFields.LastError = nil
+
+	updateFields.SetStatus = true
+	status := job.StatusDone
+	updateFields.Status = &status
+
+	s.logger.Debug("updating job fields", "jobId", j.ID, "updateFields", updateFields)
+	err = s.UpdateJobFields(ctx, j.ID, &updateFields)
+	if err != nil {
+		return fmt.Errorf("updating job fields: %w", err)
+	}
+
+	return nil
+}
+
+func (s *JobService) UpdateJobStatus(ctx context.Context, jobId string, newStatus job.Status) error {
+	j, err := s.GetJob(ctx, jobId)
+	if err != nil {
 		return err
 	}
 
+	return s.updateJobStatus(ctx, j, newStatus)
+}
+
+// CancelJob cancels the job of the account, the jobs of other accounts aren't found.
+func (s *JobService) CancelJob(ctx context.Context, accountId, jobId string) error {
+	j, err := s.GetAccountJob(ctx, accountId, jobId)
+	if err != nil {
+		return err
+	}
+
+	return s.updateJobStatus(ctx, j, job.StatusCancelled)
+}
+
+func (s *JobService) updateJobStatus(ctx context.Context, j *job.Job, newStatus job.Status) error {
 	if !job.IsValidStatusTransition(j.Status, newStatus) {
 		return fmt.Errorf("%w from %q to %q", ErrInvalidStatusTransition, j.Status, job.StatusQueued)
 	}
 
+	previousStatus := j.Status
 	j.Status = newStatus
-	return s.store.Job().Update(ctx, j)
+
+	err := s.store.Job().Update(ctx, j)
+	if err != nil {
+		return err
+	}
+
+	s.recordStatusChange(ctx, j, previousStatus)
+
+	return nil
+}
+
+// UpdateJobProgress stores the progress reported by the executor of a running job.
+func (s *JobService) UpdateJobProgress(ctx context.Context, jobId string, percent int, message string) error {
+	v := validator.New()
+	v.Check(percent >= 0 && percent <= 100, "percent", "must be between 0 and 100")
+	v.Check(len(message) <= job.MaxProgressMessageLength, "message", fmt.Sprintf("must not be more than %d bytes long", job.MaxProgressMessageLength))
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	progress := &job.Progress{
+		Percent:   percent,
+		Message:   message,
+		UpdatedAt: time.Now(),
+	}
+
+	err := s.store.Job().UpdateProgress(ctx, jobId, progress)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ClearJobProgress removes the progress reported by a previous attempt of the job.
+func (s *JobService) ClearJobProgress(ctx context.Context, jobId string) error {
+	err := s.store.Job().UpdateProgress(ctx, jobId, nil)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// HeartbeatJobs records that the given jobs are still being executed.
+func (s *JobService) HeartbeatJobs(ctx context.Context, jobIds []string) error {
+	if len(jobIds) == 0 {
+		return nil
+	}
+
+	return s.store.Job().Heartbeat(ctx, jobIds, time.Now())
+}
+
+// ListJobEvents returns up to limit stored events matching the given filter, oldest first.
+func (s *JobService) ListJobEvents(ctx context.Context, filter *event.Filter, limit int) ([]*event.Event, error) {
+	return s.store.JobEvent().List(ctx, filter, limit)
+}
+
+// SubscribeJobEvents validates the filter and subscribes to the live events of its account.
+// Events are delivered unfiltered, callers should use [event.Filter.Matches] on each of them.
+func (s *JobService) SubscribeJobEvents(ctx context.Context, filter *event.Filter) (event.Subscription, error) {
+	v := validator.New()
+	s.validateEventFilter(v, filter)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	return s.broker.Subscribe(ctx, filter.AccountID)
+}
+
+// recordStatusChange stores and publishes an event for the current status of the job.
+// Failures are only logged since the status change itself was already persisted.
+func (s *JobService) recordStatusChange(ctx context.Context, j *job.Job, previousStatus job.Status) {
+	// jobs created before accounts owned jobs can't be streamed to anyone
+	if j.AccountID == "" {
+		return
+	}
+
+	e := &event.Event{
+		JobID:          j.ID,
+		AccountID:      j.AccountID,
+		Task:           j.Task,
+		Status:         j.Status,
+		PreviousStatus: previousStatus,
+		CreatedAt:      time.Now(),
+	}
+
+	err := s.store.JobEvent().Save(ctx, e)
+	if err != nil {
+		s.logger.Error("failed to store job event", "jobID", j.ID, "status", j.Status, "err", err.Error())
+		return
+	}
+
+	err = s.broker.Publish(ctx, e)
+	if err != nil {
+		s.logger.Error("failed to publish job event", "jobID", j.ID, "eventID", e.ID, "err", err.Error())
+	}
+}
+
+func (s *JobService) validateEventFilter(v *validator.Validator, filter *event.Filter) {
+	if filter.Task != "" {
+		v.Check(registry.Exists(filter.Task), "task", "unsupported task")
+	}
+	if filter.Status != "" {
+		v.Check(slices.Contains(job.StatusList, filter.Status), "status", "unsupported status")
+	}
+	v.Check(filter.AfterID >= 0, "last_event_id", "must be equal or greater than 0")
 }
 
 func (s *JobService) validateSearchJobs(v *validator.Validator, criteria *job.SearchCriteria) {
+	s.validateSearchFilters(v, criteria)
+	pagination.Validate(v, &criteria.Params, true)
+}
+
+func (s *JobService) validateExportJobs(v *validator.Validator, criteria *job.SearchCriteria) {
+	s.validateSearchFilters(v, criteria)
+	pagination.ValidateSort(v, &criteria.Params, true)
+}
+
+func (s *JobService) validateSearchFilters(v *validator.Validator, criteria *job.SearchCriteria) {
 	if criteria.Task != "" {
-		v.Check(slices.Contains(task.Tasks, criteria.Task), "task", "unsupported task")
+		v.Check(registry.Exists(criteria.Task), "task", "unsupported task")
 	}
-	if criteria.Status != "" {
-		v.Check(slices.Contains(job.StatusList, criteria.Status), "status", "unsupported status")
+	for _, status := range criteria.Statuses {
+		v.Check(slices.Contains(job.StatusList, status), "status", "unsupported status")
+	}
+	v.Check(criteria.RetriesGTE == nil || *criteria.RetriesGTE >= 0, "retries_gte", "must be equal or greater than 0")
+	v.Check(len(criteria.Query) <= job.MaxSearchQueryLength, "q", fmt.Sprintf("must not be more than %d bytes long", job.MaxSearchQueryLength))
+	for _, tag := range criteria.Tags {
+		v.Check(tag != "", "tag", "must not be empty")
+	}
+	for key := range criteria.Metadata {
+		v.Check(key != "", "metadata", "keys must not be empty")
 	}
-	pagination.Validate(v, &criteria.Params, true)
 
 }
 
 func (s *JobService) validateCreateJob(v *validator.Validator, request *job.CreateRequest) {
 	v.CheckRequired(request.Task != "", "task")
-	v.Check(slices.Contains(task.Tasks, request.Task), "task", "unsupported task")
+	v.Check(registry.Exists(request.Task), "task", "unsupported task")
+	v.Check(len(request.IdempotencyKey) <= job.MaxIdempotencyKeyLength, "idempotency_key", fmt.Sprintf("must not be more than %d bytes long", job.MaxIdempotencyKeyLength))
 	v.CheckRequired(len(request.Payload) > 0, "payload")
 	v.Check(request.RunAt == nil || request.RunAt.After(time.Now()), "run_at", "must be in the future")
 	v.Check(request.MaxRetries == nil || *request.MaxRetries >= 0, "max_retries", "if set must be equal or greater than 0")
 	v.Check(request.RetryDelaySec == nil || *request.RetryDelaySec > 0, "retry_delay_sec", "if set must be greater than 0")
+	v.Check(request.ExpiresAt == nil || request.ExpiresAt.After(time.Now()), "expires_at", "must be in the future")
+	if request.ExpiresAt != nil && request.RunAt != nil {
+		v.Check(request.RunAt.Before(*request.ExpiresAt), "expires_at", "must be after run_at")
+	}
 
-	_, err := task.DecodeAndValidatePayload(request.Task, request.Payload, v)
+	v.Check(len(request.Tags) <= job.MaxTags, "tags", fmt.Sprintf("must not have more than %d tags", job.MaxTags))
+	for _, tag := range request.Tags {
+		v.Check(tag != "", "tags", "must not contain empty tags")
+		v.Check(len(tag) <= job.MaxTagLength, "tags", fmt.Sprintf("must not contain tags longer than %d bytes", job.MaxTagLength))
+	}
+	v.Check(len(request.Metadata) <= job.MaxMetadataKeys, "metadata", fmt.Sprintf("must not have more than %d keys", job.MaxMetadataKeys))
+	for key, value := range request.Metadata {
+		v.Check(key != "", "metadata", "must not contain empty keys")
+		v.Check(len(key) <= job.MaxMetadataKeyLength, "metadata", fmt.Sprintf("must not contain keys longer than %d bytes", job.MaxMetadataKeyLength))
+		v.Check(len(value) <= job.MaxMetadataValueLength, "metadata", fmt.Sprintf("must not contain values longer than %d bytes", job.MaxMetadataValueLength))
+	}
+
+	payload, err := registry.DecodeAndValidatePayload(request.Task, request.Payload, v)
 	if err != nil {
 		v.AddError("payload", "invalid payload for task")
 	}
+
+	if p, ok := payload.(task.WebhookPayload); ok && p.Auth != nil {
+		v.Check(s.secretsEnabled, "payload.auth", secretsDisabledMessage)
+	}
 }
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// how many expired leases are handled per call of ExpireLeases
+const expireLeasesBatchSize = 100
+
+var ErrLeaseExpired = errors.New("the lease of the job expired before its outcome was reported")
+
+// LeaseExternalJobs hands the due external jobs of the given tasks to the caller,
+// marking them running until the lease expires. The lease is empty when there are
+// no due jobs.
+func (s *JobService) LeaseExternalJobs(ctx context.Context, accountId string, request *job.LeaseRequest) (*job.Lease, error) {
+	v := validator.New()
+	s.validateLeaseRequest(v, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	limit := job.DefaultLeaseLimit
+	if request.Limit != nil {
+		limit = *request.Limit
+	}
+
+	leaseSec := job.DefaultLeaseSec
+	if request.LeaseSec != nil {
+		leaseSec = *request.LeaseSec
+	}
+
+	now := time.Now()
+
+	criteria := &job.LeaseCriteria{
+		AccountID: accountId,
+		Tasks:     request.Tasks,
+		Limit:     limit,
+		LeaseID:   uuid.NewString(),
+		Now:       now,
+		ExpiresAt: now.Add(time.Duration(leaseSec) * time.Second),
+	}
+
+	jobs, err := s.store.Job().Lease(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range jobs {
+		s.recordStatusChange(ctx, j, job.StatusQueued)
+	}
+
+	return &job.Lease{ID: criteria.LeaseID, ExpiresAt: criteria.ExpiresAt, Jobs: jobs}, nil
+}
+
+// HeartbeatLeasedJob extends the lease of the job, reporting its progress if given,
+// and returns when the lease expires.
+func (s *JobService) HeartbeatLeasedJob(ctx context.Context, accountId, jobId string, request *job.LeaseHeartbeatRequest) (time.Time, error) {
+	v := validator.New()
+	validateLeaseID(v, request.LeaseID)
+	v.Check(request.LeaseSec == nil || (*request.LeaseSec > 0 && *request.LeaseSec <= job.MaxLeaseSec), "lease_sec", fmt.Sprintf("if set must be between 1 and %d", job.MaxLeaseSec))
+	if !v.Valid() {
+		return time.Time{}, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	_, err := s.leasedJob(ctx, accountId, jobId, request.LeaseID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	leaseSec := job.DefaultLeaseSec
+	if request.LeaseSec != nil {
+		leaseSec = *request.LeaseSec
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(leaseSec) * time.Second)
+
+	err = s.store.Job().ExtendLease(ctx, jobId, request.LeaseID, expiresAt, now)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return time.Time{}, ErrLeaseLost
+		}
+		return time.Time{}, err
+	}
+
+	if request.Progress != nil {
+		err = s.UpdateJobProgress(ctx, jobId, request.Progress.Percent, request.Progress.Message)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return expiresAt, nil
+}
+
+// CompleteLeasedJob finishes the job as done.
+func (s *JobService) CompleteLeasedJob(ctx context.Context, accountId, jobId string, request *job.LeaseCompleteRequest) error {
+	v := validator.New()
+	validateLeaseID(v, request.LeaseID)
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	j, err := s.leasedJob(ctx, accountId, jobId, request.LeaseID)
+	if err != nil {
+		return err
+	}
+
+	return s.FinishJobAttempt(ctx, j, nil)
+}
+
+// FailLeasedJob fails the attempt of the job, which is retried like the ones run
+// by the worker, see [JobService.FinishJobAttempt].
+func (s *JobService) FailLeasedJob(ctx context.Context, accountId, jobId string, request *job.LeaseFailRequest) error {
+	v := validator.New()
+	validateLeaseID(v, request.LeaseID)
+	v.CheckRequired(request.Error != "", "error")
+	v.Check(len(request.Error) <= job.MaxLeaseErrorLength, "error", fmt.Sprintf("must not be more than %d bytes long", job.MaxLeaseErrorLength))
+	v.Check(len(request.Code) <= job.MaxLeaseErrorCodeLength, "code", fmt.Sprintf("must not be more than %d bytes long", job.MaxLeaseErrorCodeLength))
+	v.Check(request.RetryAfterSec == nil || *request.RetryAfterSec >= 0, "retry_after_sec", "if set must be equal or greater than 0")
+	if !v.Valid() {
+		return &validator.ValidationError{Errors: v.Errors}
+	}
+
+	j, err := s.leasedJob(ctx, accountId, jobId, request.LeaseID)
+	if err != nil {
+		return err
+	}
+
+	execErr := &task.ExecutionError{
+		Err:       errors.New(request.Error),
+		Permanent: request.Permanent,
+		Code:      request.Code,
+	}
+	if request.RetryAfterSec != nil {
+		execErr.RetryAfter = time.Duration(*request.RetryAfterSec) * time.Second
+	}
+
+	return s.FinishJobAttempt(ctx, j, execErr)
+}
+
+// ExpireLeases fails the attempts of a batch of jobs whose lease expired before
+// now, e.g. because their consumer died, and returns how many there were.
+func (s *JobService) ExpireLeases(ctx context.Context, now time.Time) (int, error) {
+	jobs, err := s.store.Job().GetExpiredLeases(ctx, now, expireLeasesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, j := range jobs {
+		err := s.finishJobAttempt(ctx, j, &task.ExecutionError{Err: ErrLeaseExpired, Code: "lease_expired"}, &now)
+		if err != nil {
+			// the consumer reported the outcome or extended the lease meanwhile
+			if errors.Is(err, ErrLeaseLost) {
+				continue
+			}
+			return 0, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// leasedJob returns the job of the account, if it's running under the lease.
+func (s *JobService) leasedJob(ctx context.Context, accountId, jobId, leaseId string) (*job.Job, error) {
+	j, err := s.GetAccountJob(ctx, accountId, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.Status != job.StatusRunning || j.LeaseID == nil || *j.LeaseID != leaseId {
+		return nil, ErrLeaseLost
+	}
+
+	return j, nil
+}
+
+func (s *JobService) validateLeaseRequest(v *validator.Validator, request *job.LeaseRequest) {
+	v.CheckRequired(len(request.Tasks) > 0, "tasks")
+	v.Check(len(request.Tasks) <= job.MaxLeaseTasks, "tasks", fmt.Sprintf("must not have more than %d tasks", job.MaxLeaseTasks))
+	v.Check(!slices.ContainsFunc(request.Tasks, func(name string) bool { return !task.ExternalTaskNameRX.MatchString(name) }),
+		"tasks", "must be names of external tasks")
+	v.Check(request.Limit == nil || (*request.Limit > 0 && *request.Limit <= job.MaxLeaseLimit), "limit", fmt.Sprintf("if set must be between 1 and %d", job.MaxLeaseLimit))
+	v.Check(request.LeaseSec == nil || (*request.LeaseSec > 0 && *request.LeaseSec <= job.MaxLeaseSec), "lease_sec", fmt.Sprintf("if set must be between 1 and %d", job.MaxLeaseSec))
+}
+
+func validateLeaseID(v *validator.Validator, leaseId string) {
+	v.CheckRequired(leaseId != "", "lease_id")
+	v.Check(leaseId == "" || uuid.Validate(leaseId) == nil, "lease_id", "must be a valid id")
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// secretsDisabledMessage is the validation error of the requests storing account
+// secrets, or referencing them, on a deployment without a secrets key.
+const secretsDisabledMessage = "can't be used, the server has no secrets key configured"
+
+type SecretService struct {
+	logger *slog.Logger
+	store  store.Store
+	cipher *secret.Cipher
+}
+
+func NewSecretService(logger *slog.Logger, store store.Store, cipher *secret.Cipher) *SecretService {
+	return &SecretService{logger: logger, store: store, cipher: cipher}
+}
+
+// PutSecret creates the account's secret with the given name, or replaces its value if it exists.
+func (s *SecretService) PutSecret(ctx context.Context, accountId, name string, request *secret.PutRequest) (*secret.Secret, error) {
+	v := validator.New()
+	s.validatePutSecret(v, name, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	sec := secret.New(accountId, name, s.cipher.Encrypt([]byte(request.Value)))
+
+	err := s.store.Secret().Save(ctx, sec)
+	if err != nil {
+		s.logger.Error("failed to store secret", "name", name, "err", err.Error())
+		return nil, err
+	}
+
+	return sec, nil
+}
+
+// GetSecrets returns the account's secrets, without their values.
+func (s *SecretService) GetSecrets(ctx context.Context, accountId string) ([]*secret.Secret, error) {
+	return s.store.Secret().GetByAccountId(ctx, accountId)
+}
+
+// GetSecretValue returns the decrypted value of the account's secret.
+func (s *SecretService) GetSecretValue(ctx context.Context, name, accountId string) (string, error) {
+	sec, err := s.store.Secret().Get(ctx, name, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return "", ErrRecordNotFound
+		}
+		return "", err
+	}
+
+	value, err := s.cipher.Decrypt(sec.Value)
+	if err != nil {
+		return "", fmt.Errorf("decrypting secret %q: %w", name, err)
+	}
+
+	return string(value), nil
+}
+
+func (s *SecretService) DeleteSecret(ctx context.Context, name, accountId string) error {
+	err := s.store.Secret().Delete(ctx, name, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SecretService) validatePutSecret(v *validator.Validator, name string, request *secret.PutRequest) {
+	v.Check(len(name) <= secret.MaxNameLength, "name", fmt.Sprintf("must not be more than %d bytes long", secret.MaxNameLength))
+	v.Check(secret.NameRX.MatchString(name), "name", "must only contain letters, digits, '.', '_' and '-'")
+
+	v.CheckRequired(request.Value != "", "value")
+	v.Check(s.cipher != nil, "value", secretsDisabledMessage)
+	v.Check(len(request.Value) <= secret.MaxValueLength, "value", fmt.Sprintf("must not be more than %d bytes long", secret.MaxValueLength))
+}
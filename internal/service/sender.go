@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/sender"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// TXTResolver looks up the TXT records of a domain, see [net.Resolver].
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type SenderIdentityService struct {
+	logger   *slog.Logger
+	store    store.Store
+	resolver TXTResolver
+}
+
+func NewSenderIdentityService(logger *slog.Logger, store store.Store, resolver TXTResolver) *SenderIdentityService {
+	return &SenderIdentityService{logger: logger, store: store, resolver: resolver}
+}
+
+// CreateSenderIdentity creates an unverified identity. It can be sent from once
+// its verification token is published and VerifySenderIdentity called.
+func (s *SenderIdentityService) CreateSenderIdentity(ctx context.Context, accountId string, request *sender.IdentityRequest) (*sender.Identity, error) {
+	v := validator.New()
+	s.validateSenderIdentity(v, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	identity := sender.NewIdentity(accountId, request.Address)
+
+	err := s.store.SenderIdentity().Save(ctx, identity)
+	if err != nil {
+		if errors.Is(err, store.ErrDuplicateRecord) {
+			return nil, &validator.ValidationError{Errors: map[string]string{"address": "an identity with this address already exists"}}
+		}
+		s.logger.Error("failed to store sender identity", "err", err.Error())
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (s *SenderIdentityService) GetSenderIdentities(ctx context.Context, accountId string) ([]*sender.Identity, error) {
+	return s.store.SenderIdentity().GetByAccountId(ctx, accountId)
+}
+
+func (s *SenderIdentityService) GetSenderIdentity(ctx context.Context, id, accountId string) (*sender.Identity, error) {
+	identity, err := s.store.SenderIdentity().Get(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// VerifySenderIdentity marks the identity verified if its verification token is
+// published in the TXT record of its domain. Otherwise it returns a validation error.
+func (s *SenderIdentityService) VerifySenderIdentity(ctx context.Context, id, accountId string) (*sender.Identity, error) {
+	identity, err := s.GetSenderIdentity(ctx, id, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.Verified() {
+		return identity, nil
+	}
+
+	records, err := s.resolver.LookupTXT(ctx, identity.VerificationRecord())
+	if err != nil {
+		var dnsError *net.DNSError
+		if !errors.As(err, &dnsError) || !dnsError.IsNotFound {
+			return nil, fmt.Errorf("looking up %s: %w", identity.VerificationRecord(), err)
+		}
+	}
+
+	if !slices.ContainsFunc(records, func(record string) bool { return strings.TrimSpace(record) == identity.VerificationToken }) {
+		message := fmt.Sprintf("the TXT record %s must contain the verification token", identity.VerificationRecord())
+		return nil, &validator.ValidationError{Errors: map[string]string{"verification_token": message}}
+	}
+
+	now := time.Now()
+
+	err = s.store.SenderIdentity().MarkVerified(ctx, id, accountId, now)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+	identity.VerifiedAt = &now
+
+	return identity, nil
+}
+
+func (s *SenderIdentityService) DeleteSenderIdentity(ctx context.Context, id, accountId string) error {
+	err := s.store.SenderIdentity().Delete(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SenderIdentityService) validateSenderIdentity(v *validator.Validator, request *sender.IdentityRequest) {
+	v.CheckRequired(request.Address != "", "address")
+	v.Check(len(request.Address) <= sender.MaxAddressLength, "address", fmt.Sprintf("must not be more than %d bytes long", sender.MaxAddressLength))
+	v.Check(sender.ValidAddress(request.Address), "address", "must be an email address or a domain")
+}
+
+type SMTPConfigService struct {
+	logger *slog.Logger
+	store  store.Store
+	cipher *secret.Cipher
+}
+
+func NewSMTPConfigService(logger *slog.Logger, store store.Store, cipher *secret.Cipher) *SMTPConfigService {
+	return &SMTPConfigService{logger: logger, store: store, cipher: cipher}
+}
+
+// PutSMTPConfig sets the SMTP server the account's emails are sent through.
+func (s *SMTPConfigService) PutSMTPConfig(ctx context.Context, accountId string, request *sender.SMTPConfigRequest) (*sender.SMTPConfig, error) {
+	v := validator.New()
+	s.validateSMTPConfig(v, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	config := sender.NewSMTPConfig(accountId, request, s.cipher.Encrypt([]byte(request.Password)))
+
+	err := s.store.SMTPConfig().Save(ctx, config)
+	if err != nil {
+		s.logger.Error("failed to store smtp config", "err", err.Error())
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetSMTPConfig returns the account's SMTP config, with its password still encrypted.
+func (s *SMTPConfigService) GetSMTPConfig(ctx context.Context, accountId string) (*sender.SMTPConfig, error) {
+	config, err := s.store.SMTPConfig().Get(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetSMTPPassword returns the decrypted password of the SMTP config.
+func (s *SMTPConfigService) GetSMTPPassword(config *sender.SMTPConfig) (string, error) {
+	password, err := s.cipher.Decrypt(config.Password)
+	if err != nil {
+		return "", fmt.Errorf("decrypting smtp password: %w", err)
+	}
+
+	return string(password), nil
+}
+
+func (s *SMTPConfigService) DeleteSMTPConfig(ctx context.Context, accountId string) error {
+	err := s.store.SMTPConfig().Delete(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SMTPConfigService) validateSMTPConfig(v *validator.Validator, request *sender.SMTPConfigRequest) {
+	v.Check(s.cipher != nil, "password", secretsDisabledMessage)
+
+	v.CheckRequired(request.Host != "", "host")
+	v.Check(len(request.Host) <= sender.MaxHostLength, "host", fmt.Sprintf("must not be more than %d bytes long", sender.MaxHostLength))
+	_, err := netip.ParseAddr(request.Host)
+	v.Check(err == nil || sender.HostRX.MatchString(request.Host), "host", "must be a hostname or an IP address")
+
+	v.Check(request.Port >= 1 && request.Port <= 65535, "port", "must be between 1 and 65535")
+
+	v.Check(len(request.Username) <= sender.MaxUsernameLength, "username", fmt.Sprintf("must not be more than %d bytes long", sender.MaxUsernameLength))
+	v.Check(len(request.Password) <= sender.MaxPasswordLength, "password", fmt.Sprintf("must not be more than %d bytes long", sender.MaxPasswordLength))
+	if request.Username != "" {
+		v.CheckRequired(request.Password != "", "password")
+	}
+}
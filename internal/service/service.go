@@ -9,6 +9,7 @@ import (
 var (
 	ErrRecordNotFound          = store.ErrRecordNotFound
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrLeaseLost               = errors.New("the job isn't running under the given lease")
 
 	ErrComparingPasswords = errors.New("error authenticating")
 	ErrInvalidCredentials = errors.New("invalid credentials provided")
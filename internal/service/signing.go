@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/signing"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+type SigningSecretService struct {
+	logger *slog.Logger
+	store  store.Store
+	cipher *secret.Cipher
+}
+
+func NewSigningSecretService(logger *slog.Logger, store store.Store, cipher *secret.Cipher) *SigningSecretService {
+	return &SigningSecretService{logger: logger, store: store, cipher: cipher}
+}
+
+// RotateSigningSecret creates a new signing secret for the account. Its previous
+// secrets keep signing webhooks until the grace period of the request ends.
+func (s *SigningSecretService) RotateSigningSecret(ctx context.Context, accountId string, request *signing.RotateRequest) (*signing.Secret, error) {
+	v := validator.New()
+	s.validateRotateSigningSecret(v, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	gracePeriod := signing.DefaultRotationGracePeriod
+	if request.GracePeriodSec != nil {
+		gracePeriod = time.Duration(*request.GracePeriodSec) * time.Second
+	}
+
+	sec := signing.New(accountId)
+	sec.Value = s.cipher.Encrypt([]byte(sec.Secret))
+
+	err := s.store.SigningSecret().Rotate(ctx, sec, sec.CreatedAt.Add(gracePeriod))
+	if err != nil {
+		s.logger.Error("failed to store signing secret", "err", err.Error())
+		return nil, err
+	}
+
+	return sec, nil
+}
+
+// GetSigningSecrets returns the active secrets of the account without their value.
+func (s *SigningSecretService) GetSigningSecrets(ctx context.Context, accountId string) ([]*signing.Secret, error) {
+	secrets, err := s.store.SigningSecret().GetActive(ctx, accountId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sec := range secrets {
+		sec.Secret = ""
+	}
+
+	return secrets, nil
+}
+
+// GetActiveSecrets returns the values of the secrets the account's webhooks are signed with.
+func (s *SigningSecretService) GetActiveSecrets(ctx context.Context, accountId string) ([]string, error) {
+	secrets, err := s.store.SigningSecret().GetActive(ctx, accountId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(secrets))
+	for i, sec := range secrets {
+		// secrets created before they were encrypted are stored in plaintext
+		if sec.Value == nil {
+			values[i] = sec.Secret
+			continue
+		}
+
+		value, err := s.cipher.Decrypt(sec.Value)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting signing secret %q: %w", sec.ID, err)
+		}
+		values[i] = string(value)
+	}
+
+	return values, nil
+}
+
+func (s *SigningSecretService) DeleteSigningSecret(ctx context.Context, id, accountId string) error {
+	err := s.store.SigningSecret().Delete(ctx, id, accountId)
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SigningSecretService) validateRotateSigningSecret(v *validator.Validator, request *signing.RotateRequest) {
+	v.Check(s.cipher != nil, "secret", secretsDisabledMessage)
+
+	if request.GracePeriodSec != nil {
+		maxSec := int(signing.MaxRotationGracePeriod / time.Second)
+		v.Check(*request.GracePeriodSec >= 0 && *request.GracePeriodSec <= maxSec, "grace_period_sec", fmt.Sprintf("must be between 0 and %d", maxSec))
+	}
+}
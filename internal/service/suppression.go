@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/suppression"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+type SuppressionService struct {
+	logger *slog.Logger
+	store  store.Store
+}
+
+func NewSuppressionService(logger *slog.Logger, store store.Store) *SuppressionService {
+	return &SuppressionService{logger: logger, store: store}
+}
+
+// AddSuppression stops the emails of the account from being sent to the address.
+// Adding an address that is already suppressed replaces its reason.
+func (s *SuppressionService) AddSuppression(ctx context.Context, accountId string, request *suppression.Request) (*suppression.Suppression, error) {
+	if request.Reason == "" {
+		request.Reason = suppression.ReasonManual
+	}
+
+	v := validator.New()
+	s.validateSuppression(v, request)
+	if !v.Valid() {
+		return nil, &validator.ValidationError{Errors: v.Errors}
+	}
+
+	sup := suppression.NewSuppression(accountId, request.Address, request.Reason, request.Detail)
+
+	err := s.store.Suppression().Save(ctx, sup)
+	if err != nil {
+		s.logger.Error("failed to store suppression", "err", err.Error())
+		return nil, err
+	}
+
+	return sup, nil
+}
+
+func (s *SuppressionService) GetSuppressions(ctx context.Context, accountId string) ([]*suppression.Suppression, error) {
+	return s.store.Suppression().GetByAccountId(ctx, accountId)
+}
+
+func (s *SuppressionService) GetSuppression(ctx context.Context, accountId, address string) (*suppression.Suppression, error) {
+	sup, err := s.store.Suppression().Get(ctx, accountId, suppression.NormalizeAddress(address))
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return sup, nil
+}
+
+// GetSuppressedAddresses returns the suppressions of the account among the
+// addresses, which may have display names.
+func (s *SuppressionService) GetSuppressedAddresses(ctx context.Context, accountId string, addresses []string) ([]*suppression.Suppression, error) {
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if parsed, err := mail.ParseAddress(address); err == nil {
+			address = parsed.Address
+		}
+		normalized = append(normalized, suppression.NormalizeAddress(address))
+	}
+	slices.Sort(normalized)
+
+	return s.store.Suppression().GetByAddresses(ctx, accountId, slices.Compact(normalized))
+}
+
+// DeleteSuppression allows sending emails to the address again.
+func (s *SuppressionService) DeleteSuppression(ctx context.Context, accountId, address string) error {
+	err := s.store.Suppression().Delete(ctx, accountId, suppression.NormalizeAddress(address))
+	if err != nil {
+		if errors.Is(err, store.ErrNoRowsAffected) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// IngestNotification suppresses the addresses a bounce or complaint notification
+// says must be, see [suppression.ParseNotification] for the supported formats.
+// It returns the added suppressions, which are none for transient bounces.
+func (s *SuppressionService) IngestNotification(ctx context.Context, accountId, contentType string, body []byte) ([]*suppression.Suppression, error) {
+	events, err := suppression.ParseNotification(contentType, body)
+	if err != nil {
+		return nil, &validator.ValidationError{Errors: map[string]string{"notification": err.Error()}}
+	}
+
+	suppressions := []*suppression.Suppression{}
+	for _, event := range events {
+		if !suppression.ValidAddress(event.Address) || len(event.Address) > suppression.MaxAddressLength {
+			s.logger.Warn("skipping invalid address of notification", "accountId", accountId, "address", event.Address)
+			continue
+		}
+
+		detail := event.Detail
+		if len(detail) > suppression.MaxDetailLength {
+			detail = detail[:suppression.MaxDetailLength]
+		}
+		sup := suppression.NewSuppression(accountId, event.Address, event.Reason, detail)
+
+		err := s.store.Suppression().Save(ctx, sup)
+		if err != nil {
+			s.logger.Error("failed to store suppression", "err", err.Error())
+			return nil, err
+		}
+
+		suppressions = append(suppressions, sup)
+	}
+
+	return suppressions, nil
+}
+
+func (s *SuppressionService) validateSuppression(v *validator.Validator, request *suppression.Request) {
+	v.CheckRequired(request.Address != "", "address")
+	v.Check(len(request.Address) <= suppression.MaxAddressLength, "address", fmt.Sprintf("must not be more than %d bytes long", suppression.MaxAddressLength))
+	v.Check(suppression.ValidAddress(request.Address), "address", "must be an email address")
+	v.Check(slices.Contains(suppression.ReasonList, request.Reason), "reason", fmt.Sprintf("must be one of %v", suppression.ReasonList))
+	v.Check(len(request.Detail) <= suppression.MaxDetailLength, "detail", fmt.Sprintf("must not be more than %d bytes long", suppression.MaxDetailLength))
+}
@@ -0,0 +1,43 @@
+package signing
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const secretPrefix = "whsec_"
+
+// DefaultRotationGracePeriod is how long the previous secrets keep signing webhooks after a rotation
+const DefaultRotationGracePeriod = 24 * time.Hour
+
+// MaxRotationGracePeriod is the longest an account can keep the previous secrets after a rotation
+const MaxRotationGracePeriod = 7 * 24 * time.Hour
+
+// Secret signs the webhooks of an account. While an account has more than one
+// active secret, webhooks are signed with each of them.
+type Secret struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	Secret    string `json:"secret,omitempty"`
+	// Encrypted Secret, the one stored, the plaintext is only returned when the secret is created
+	Value     []byte     `json:"-"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func New(accountId string) *Secret {
+	return &Secret{
+		ID:        uuid.NewString(),
+		AccountID: accountId,
+		Secret:    fmt.Sprintf("%s%s", secretPrefix, rand.Text()),
+		CreatedAt: time.Now(),
+	}
+}
+
+type RotateRequest struct {
+	// Seconds the previous secrets stay valid for. Defaults to DefaultRotationGracePeriod, 0 expires them right away
+	GracePeriodSec *int `json:"grace_period_sec"`
+}
@@ -50,7 +50,7 @@ func (s *PostgresAccountStore) Save(ctx context.Context, account *account.Accoun
 }
 
 func (s *PostgresAccountStore) Get(ctx context.Context, id string) (*account.Account, error) {
-	query := `SELECT id, name, email, password_hash, activated, created_at
+	query := `SELECT id, name, email, password_hash, activated, created_at, job_retention_days
 	FROM accounts
 	WHERE id = $1`
 
@@ -66,6 +66,7 @@ func (s *PostgresAccountStore) Get(ctx context.Context, id string) (*account.Acc
 		&acc.Password.Hash,
 		&acc.Activated,
 		&acc.CreatedAt,
+		&acc.JobRetentionDays,
 	)
 
 	if err != nil {
@@ -81,7 +82,7 @@ func (s *PostgresAccountStore) Get(ctx context.Context, id string) (*account.Acc
 }
 
 func (s *PostgresAccountStore) GetByEmail(ctx context.Context, email string) (*account.Account, error) {
-	query := `SELECT id, name, email, password_hash, activated, created_at
+	query := `SELECT id, name, email, password_hash, activated, created_at, job_retention_days
 	FROM accounts
 	WHERE email = $1`
 
@@ -97,6 +98,7 @@ func (s *PostgresAccountStore) GetByEmail(ctx context.Context, email string) (*a
 		&acc.Password.Hash,
 		&acc.Activated,
 		&acc.CreatedAt,
+		&acc.JobRetentionDays,
 	)
 
 	if err != nil {
@@ -112,7 +114,8 @@ func (s *PostgresAccountStore) GetByEmail(ctx context.Context, email string) (*a
 }
 
 func (s *PostgresAccountStore) GetForToken(ctx context.Context, hash []byte, scope token.Scope, now time.Time) (*account.Account, error) {
-	query := `SELECT accounts.id, accounts.name, accounts.email, accounts.password_hash, accounts.activated, accounts.created_at
+	query := `SELECT accounts.id, accounts.name, accounts.email, accounts.password_hash, accounts.activated, accounts.created_at,
+	accounts.job_retention_days
 	FROM accounts
 	INNER JOIN tokens
 	ON accounts.id = tokens.account_id
@@ -134,6 +137,7 @@ func (s *PostgresAccountStore) GetForToken(ctx context.Context, hash []byte, sco
 		&acc.Password.Hash,
 		&acc.Activated,
 		&acc.CreatedAt,
+		&acc.JobRetentionDays,
 	)
 
 	if err != nil {
@@ -147,3 +151,28 @@ func (s *PostgresAccountStore) GetForToken(ctx context.Context, hash []byte, sco
 
 	return &acc, nil
 }
+
+func (s *PostgresAccountStore) UpdateJobRetention(ctx context.Context, id string, days *int) error {
+	query := `UPDATE accounts
+	SET job_retention_days = $1
+	WHERE id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, days, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
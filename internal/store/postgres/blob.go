@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+type PostgresBlobStore struct {
+	*PostgresStore
+}
+
+func newPostgresBlobStore(postgresStore *PostgresStore) store.BlobStore {
+	s := &PostgresBlobStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+func (s *PostgresBlobStore) Save(ctx context.Context, b *blob.Blob) error {
+	query := `INSERT INTO blobs (id, account_id, filename, content_type, size, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+
+	args := []any{b.ID, b.AccountID, b.Filename, b.ContentType, b.Size, b.CreatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s *PostgresBlobStore) Get(ctx context.Context, id, accountId string) (*blob.Blob, error) {
+	query := `SELECT id, account_id, filename, content_type, size, created_at
+	FROM blobs
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	var b blob.Blob
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&b.ID,
+		&b.AccountID,
+		&b.Filename,
+		&b.ContentType,
+		&b.Size,
+		&b.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &b, nil
+}
+
+func (s *PostgresBlobStore) Delete(ctx context.Context, id, accountId string) error {
+	query := `DELETE FROM blobs
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ngmmartins/asyncq/internal/emailtemplate"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+// unique_violation, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const uniqueViolationCode = "23505"
+
+type PostgresEmailTemplateStore struct {
+	*PostgresStore
+}
+
+func newPostgresEmailTemplateStore(postgresStore *PostgresStore) store.EmailTemplateStore {
+	s := &PostgresEmailTemplateStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+func (s *PostgresEmailTemplateStore) Save(ctx context.Context, template *emailtemplate.Template) error {
+	query := `INSERT INTO email_templates (id, account_id, name, subject, text_body, html_body, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	args := []any{template.ID, template.AccountID, template.Name, template.Subject, template.TextBody,
+		template.HTMLBody, template.CreatedAt, template.UpdatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return duplicateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s *PostgresEmailTemplateStore) Get(ctx context.Context, id, accountId string) (*emailtemplate.Template, error) {
+	query := `SELECT id, account_id, name, subject, text_body, html_body, created_at, updated_at
+	FROM email_templates
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	var template emailtemplate.Template
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&template.ID,
+		&template.AccountID,
+		&template.Name,
+		&template.Subject,
+		&template.TextBody,
+		&template.HTMLBody,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &template, nil
+}
+
+func (s *PostgresEmailTemplateStore) GetByAccountId(ctx context.Context, accountId string) ([]*emailtemplate.Template, error) {
+	query := `SELECT id, account_id, name, subject, text_body, html_body, created_at, updated_at
+	FROM email_templates
+	WHERE account_id = $1
+	ORDER BY name`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	templates := []*emailtemplate.Template{}
+
+	for rows.Next() {
+		var template emailtemplate.Template
+
+		err := rows.Scan(
+			&template.ID,
+			&template.AccountID,
+			&template.Name,
+			&template.Subject,
+			&template.TextBody,
+			&template.HTMLBody,
+			&template.CreatedAt,
+			&template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, &template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (s *PostgresEmailTemplateStore) Update(ctx context.Context, template *emailtemplate.Template) error {
+	query := `UPDATE email_templates
+	SET name = $3, subject = $4, text_body = $5, html_body = $6, updated_at = $7
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{template.ID, template.AccountID, template.Name, template.Subject, template.TextBody,
+		template.HTMLBody, template.UpdatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return duplicateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s *PostgresEmailTemplateStore) Delete(ctx context.Context, id, accountId string) error {
+	query := `DELETE FROM email_templates
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// duplicateError returns store.ErrDuplicateRecord if err is a unique constraint violation, err otherwise.
+func duplicateError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return store.ErrDuplicateRecord
+	}
+	return err
+}
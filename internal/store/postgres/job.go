@@ -3,15 +3,21 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/ngmmartins/asyncq/internal/job"
 	"github.com/ngmmartins/asyncq/internal/pagination"
 	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/task"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type PostgresJobStore struct {
 	*PostgresStore
 }
@@ -28,17 +34,24 @@ func newPostgresJobStore(postgresStore *PostgresStore) store.JobStore {
 //
 // If the insert doesn't change any row, a [store.ErrNoRowsAffected] error is returned.
 func (s *PostgresJobStore) Save(ctx context.Context, job *job.Job) error {
-	query := `INSERT INTO jobs (id, task, payload, run_at, status, created_at, retries, max_retries, retry_delay_sec)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	query := `INSERT INTO jobs (id, account_id, task, payload, run_at, status, created_at, retries, max_retries, retry_delay_sec, tags, metadata, expires_at,
+		idempotency_key)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	metadata, err := json.Marshal(job.Metadata)
+	if err != nil {
+		return err
+	}
 
-	args := []any{job.ID, job.Task, job.Payload, job.RunAt, job.Status, job.CreatedAt, job.Retries, job.MaxRetries, job.RetryDelaySec}
+	args := []any{job.ID, job.AccountID, job.Task, job.Payload, job.RunAt, job.Status, job.CreatedAt, job.Retries, job.MaxRetries, job.RetryDelaySec, pq.Array(job.Tags), metadata, job.ExpiresAt,
+		job.IdempotencyKey}
 
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return duplicateError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -53,22 +66,53 @@ func (s *PostgresJobStore) Save(ctx context.Context, job *job.Job) error {
 	return nil
 }
 
-func (s *PostgresJobStore) Search(ctx context.Context, criteria *job.SearchCriteria) ([]*job.Job, *pagination.Metadata, error) {
-	query := fmt.Sprintf(`SELECT count(*) OVER(), 
-	id, task, payload, run_at, status, created_at, finished_at, retries, max_retries, retry_delay_sec, last_error
-	FROM jobs
-	WHERE (task = $1 OR $1::text IS NULL OR $1 = '')
+// jobColumns are the columns read by scanJob, in the same order.
+const jobColumns = `id, COALESCE(account_id::text, ''), task, payload, run_at, status, created_at, finished_at,
+	retries, max_retries, retry_delay_sec, last_error, tags, metadata, expires_at, progress, heartbeat_at, last_error_code,
+	lease_id, lease_expires_at, idempotency_key`
+
+// searchFilters is the WHERE clause of the queries filtering jobs by a [job.SearchCriteria].
+// Its arguments are the first ones returned by searchArgs.
+const searchFilters = `WHERE (task = $1 OR $1::text IS NULL OR $1 = '')
 	AND (run_at >= $2 OR $2::timestamptz IS NULL)
 	AND (run_at <= $3 OR $3::timestamptz IS NULL)
-	AND (status = $4 OR $4::text IS NULL OR $4 = '')
+	AND (status = ANY($4::text[]) OR cardinality($4::text[]) = 0)
+	AND tags @> $5::text[]
+	AND metadata @> $6::jsonb
+	AND (created_at >= $7 OR $7::timestamptz IS NULL)
+	AND (created_at <= $8 OR $8::timestamptz IS NULL)
+	AND (finished_at >= $9 OR $9::timestamptz IS NULL)
+	AND (finished_at <= $10 OR $10::timestamptz IS NULL)
+	AND (retries >= $11 OR $11::integer IS NULL)
+	AND ((last_error IS NOT NULL) = $12 OR $12::boolean IS NULL)
+	AND (last_error ILIKE $13 OR payload::text ILIKE $13 OR $13 = '')
+	AND (heartbeat_at < $14 OR $14::timestamptz IS NULL)
+	AND account_id = $15`
+
+// Searches the jobs matching the given [job.SearchCriteria].
+//
+// When [pagination.Params].Cursor is set keyset pagination is used, which
+// doesn't compute the total number of records.
+func (s *PostgresJobStore) Search(ctx context.Context, criteria *job.SearchCriteria) ([]*job.Job, *pagination.Metadata, error) {
+	if criteria.Cursor != nil {
+		return s.searchWithCursor(ctx, criteria)
+	}
+
+	query := fmt.Sprintf(`SELECT count(*) OVER(), %s
+	FROM jobs
+	%s
 	ORDER BY %s %s, created_at DESC
-	LIMIT $5 OFFSET $6`, criteria.SortColumn(), criteria.SortDirection())
+	LIMIT $16 OFFSET $17`, jobColumns, searchFilters, criteria.SortColumn(), criteria.SortDirection())
+
+	args, err := searchArgs(criteria)
+	if err != nil {
+		return nil, nil, err
+	}
+	args = append(args, criteria.Limit(), criteria.Offset())
 
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	args := []any{criteria.Task, criteria.RunAfter, criteria.RunBefore, criteria.Status, criteria.Limit(), criteria.Offset()}
-
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, err
@@ -80,27 +124,12 @@ func (s *PostgresJobStore) Search(ctx context.Context, criteria *job.SearchCrite
 	jobs := []*job.Job{}
 
 	for rows.Next() {
-		var j job.Job
-
-		err := rows.Scan(
-			&totalRecords,
-			&j.ID,
-			&j.Task,
-			&j.Payload,
-			&j.RunAt,
-			&j.Status,
-			&j.CreatedAt,
-			&j.FinishedAt,
-			&j.Retries,
-			&j.MaxRetries,
-			&j.RetryDelaySec,
-			&j.LastError,
-		)
+		j, err := scanJob(rows, &totalRecords)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		jobs = append(jobs, &j)
+		jobs = append(jobs, j)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -112,33 +141,398 @@ func (s *PostgresJobStore) Search(ctx context.Context, criteria *job.SearchCrite
 	return jobs, metadata, nil
 }
 
+// searchWithCursor reads the page after the criteria cursor, ordering by the sort
+// column and the id. Jobs inserted meanwhile don't shift the following pages.
+func (s *PostgresJobStore) searchWithCursor(ctx context.Context, criteria *job.SearchCriteria) ([]*job.Job, *pagination.Metadata, error) {
+	column := criteria.SortColumn()
+	direction := criteria.SortDirection()
+
+	// NULLs are sorted as if they were larger than any value, which is also what
+	// 'infinity' is, so the row comparison below keeps the same order as ORDER BY.
+	sortExpression := column
+	if pagination.JobSortColumnTypes[column] == "timestamptz" {
+		sortExpression = fmt.Sprintf("COALESCE(%s, 'infinity'::timestamptz)", column)
+	}
+
+	comparison := ">"
+	if direction == "DESC" {
+		comparison = "<"
+	}
+
+	query := fmt.Sprintf(`SELECT (%[1]s)::text, %[2]s
+	FROM jobs
+	%[3]s
+	AND ($16::text IS NULL OR (%[1]s, id) %[4]s (($16::text)::%[5]s, ($17::text)::uuid))
+	ORDER BY %[1]s %[6]s, id %[6]s
+	LIMIT $18`, sortExpression, jobColumns, searchFilters, comparison, pagination.JobSortColumnTypes[column], direction)
+
+	args, err := searchArgs(criteria)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cursorValue, cursorID *string
+	if !criteria.Cursor.IsFirst() {
+		cursorValue = &criteria.Cursor.Value
+		cursorID = &criteria.Cursor.ID
+	}
+
+	// one more row is read to know if there's a next page
+	args = append(args, cursorValue, cursorID, criteria.Limit()+1)
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	jobs := []*job.Job{}
+	sortValues := []string{}
+
+	for rows.Next() {
+		var sortValue string
+
+		j, err := scanJob(rows, &sortValue)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		jobs = append(jobs, j)
+		sortValues = append(sortValues, sortValue)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *pagination.Cursor
+	if len(jobs) > criteria.Limit() {
+		jobs = jobs[:criteria.Limit()]
+
+		last := len(jobs) - 1
+		next = &pagination.Cursor{
+			SortBy: criteria.SortBy,
+			Value:  sortValues[last],
+			ID:     jobs[last].ID,
+		}
+	}
+
+	return jobs, pagination.NewCursorMetadata(criteria.PageSize, next), nil
+}
+
+// exportBatchSize is the number of rows fetched at a time from the export cursor
+const exportBatchSize = 500
+
+// Export calls fn for every job matching the given [job.SearchCriteria], ignoring
+// its pagination. Rows are fetched in batches from a server-side cursor, so
+// neither the database nor this process hold the whole result at once.
+//
+// There's no timeout besides the one of the given context since exports can take long.
+func (s *PostgresJobStore) Export(ctx context.Context, criteria *job.SearchCriteria, fn func(*job.Job) error) error {
+	query := fmt.Sprintf(`DECLARE jobs_export NO SCROLL CURSOR FOR
+	SELECT %s
+	FROM jobs
+	%s
+	ORDER BY %s %s, id`, jobColumns, searchFilters, criteria.SortColumn(), criteria.SortDirection())
+
+	args, err := searchArgs(criteria)
+	if err != nil {
+		return err
+	}
+
+	// cursors only live inside a transaction
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM jobs_export", exportBatchSize)
+
+	for {
+		fetched, err := s.exportBatch(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+
+		if fetched < exportBatchSize {
+			break
+		}
+	}
+
+	return tx.Commit()
+}
+
+// exportBatch fetches the next batch of the export cursor and calls fn for each job.
+func (s *PostgresJobStore) exportBatch(ctx context.Context, tx *sql.Tx, fetch string, fn func(*job.Job) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	fetched := 0
+
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return fetched, err
+		}
+
+		fetched++
+
+		err = fn(j)
+		if err != nil {
+			return fetched, err
+		}
+	}
+
+	return fetched, rows.Err()
+}
+
+// archiveColumns are the columns copied to jobs_archive when purging.
+// New columns of jobs must be added to both tables and to this list.
+const archiveColumns = `id, account_id, task, payload, run_at, status, created_at, finished_at,
+	retries, max_retries, retry_delay_sec, last_error, tags, metadata, expires_at, progress, heartbeat_at, last_error_code,
+	lease_id, lease_expires_at, idempotency_key`
+
+// Purge deletes up to [job.PurgeCriteria].Limit jobs past their retention period and
+// returns how many were deleted. The retention of the job's account is used when
+// set, otherwise the default one.
+//
+// When archive is given it's called with the deleted jobs before the transaction is
+// committed, so jobs are kept if it fails. Jobs are also copied to the archive table
+// when [job.PurgeCriteria].ArchiveToTable is set.
+func (s *PostgresJobStore) Purge(ctx context.Context, criteria *job.PurgeCriteria, archive func([]*job.Job) error) (int, error) {
+	var archiveTable string
+	if criteria.ArchiveToTable {
+		archiveTable = fmt.Sprintf(`, archived AS (
+		INSERT INTO jobs_archive (%[1]s, archived_at)
+		SELECT %[1]s, $2::timestamptz FROM purged
+	)`, archiveColumns)
+	}
+
+	selectPurged := "count(*)"
+	if archive != nil {
+		selectPurged = jobColumns
+	}
+
+	query := fmt.Sprintf(`WITH purged AS (
+		DELETE FROM jobs
+		WHERE id IN (
+			SELECT jobs.id
+			FROM jobs
+			LEFT JOIN accounts ON accounts.id = jobs.account_id
+			WHERE jobs.status = ANY($1::text[])
+			AND COALESCE(accounts.job_retention_days, $3) > 0
+			AND COALESCE(jobs.finished_at, jobs.created_at) < $2::timestamptz - make_interval(days => COALESCE(accounts.job_retention_days, $3))
+			ORDER BY COALESCE(jobs.finished_at, jobs.created_at)
+			LIMIT $4
+			FOR UPDATE OF jobs SKIP LOCKED
+		)
+		RETURNING *
+	)%s
+	SELECT %s FROM purged`, archiveTable, selectPurged)
+
+	statuses := make([]string, len(criteria.Statuses))
+	for i, status := range criteria.Statuses {
+		statuses[i] = string(status)
+	}
+
+	args := []any{pq.Array(statuses), criteria.Now, criteria.DefaultRetentionDays, criteria.Limit}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	purged := 0
+	jobs := []*job.Job{}
+
+	for rows.Next() {
+		if archive == nil {
+			err = rows.Scan(&purged)
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		j, err := scanJob(rows)
+		if err != nil {
+			return 0, err
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if archive != nil {
+		purged = len(jobs)
+
+		if purged > 0 {
+			err = archive(jobs)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// searchArgs returns the arguments of searchFilters for the given [job.SearchCriteria].
+func searchArgs(criteria *job.SearchCriteria) ([]any, error) {
+	// empty tags and metadata are contained in every job, so they don't filter anything
+	tags := criteria.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	metadataFilter := criteria.Metadata
+	if metadataFilter == nil {
+		metadataFilter = map[string]string{}
+	}
+	metadataJSON, err := json.Marshal(metadataFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]string, len(criteria.Statuses))
+	for i, status := range criteria.Statuses {
+		statuses[i] = string(status)
+	}
+
+	// the query is matched anywhere in the text, so LIKE wildcards it contains are escaped
+	queryPattern := ""
+	if criteria.Query != "" {
+		queryPattern = "%" + likeEscaper.Replace(criteria.Query) + "%"
+	}
+
+	args := []any{criteria.Task, criteria.RunAfter, criteria.RunBefore, pq.Array(statuses), pq.Array(tags), metadataJSON,
+		criteria.CreatedAfter, criteria.CreatedBefore, criteria.FinishedAfter, criteria.FinishedBefore,
+		criteria.RetriesGTE, criteria.HasError, queryPattern, criteria.HeartbeatBefore, criteria.AccountID}
+
+	return args, nil
+}
+
+// scanJob reads a row selected with jobColumns. The destinations of any
+// columns selected before them are given in leading.
+func scanJob(row interface{ Scan(...any) error }, leading ...any) (*job.Job, error) {
+	var j job.Job
+	var metadata, progress []byte
+
+	dest := append(leading,
+		&j.ID,
+		&j.AccountID,
+		&j.Task,
+		&j.Payload,
+		&j.RunAt,
+		&j.Status,
+		&j.CreatedAt,
+		&j.FinishedAt,
+		&j.Retries,
+		&j.MaxRetries,
+		&j.RetryDelaySec,
+		&j.LastError,
+		pq.Array(&j.Tags),
+		&metadata,
+		&j.ExpiresAt,
+		&progress,
+		&j.HeartbeatAt,
+		&j.LastErrorCode,
+		&j.LeaseID,
+		&j.LeaseExpiresAt,
+		&j.IdempotencyKey,
+	)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(metadata, &j.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// NULL until the job reports progress
+	if progress != nil {
+		err = json.Unmarshal(progress, &j.Progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &j, nil
+}
+
 // Gets the [job.Job] identified by the given jobId from the database.
 //
 // In case the record does not exist in the database a [store.ErrRecordNotFound] error is returned
 func (s *PostgresJobStore) Get(ctx context.Context, jobId string) (*job.Job, error) {
-	query := `SELECT id, task, payload, run_at, status, created_at, finished_at, retries, max_retries, retry_delay_sec, last_error
+	query := fmt.Sprintf(`SELECT %s
 	FROM jobs
-	WHERE id = $1`
-
-	var job job.Job
+	WHERE id = $1`, jobColumns)
 
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	err := s.db.QueryRowContext(ctx, query, jobId).Scan(
-		&job.ID,
-		&job.Task,
-		&job.Payload,
-		&job.RunAt,
-		&job.Status,
-		&job.CreatedAt,
-		&job.FinishedAt,
-		&job.Retries,
-		&job.MaxRetries,
-		&job.RetryDelaySec,
-		&job.LastError,
-	)
+	job, err := scanJob(s.db.QueryRowContext(ctx, query, jobId))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return job, nil
+}
 
+// GetByIdempotencyKey returns the job of the account created with the idempotency key.
+//
+// In case the record does not exist in the database a [store.ErrRecordNotFound] error is returned
+func (s *PostgresJobStore) GetByIdempotencyKey(ctx context.Context, accountId, key string) (*job.Job, error) {
+	query := fmt.Sprintf(`SELECT %s
+	FROM jobs
+	WHERE account_id = $1 AND idempotency_key = $2`, jobColumns)
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	job, err := scanJob(s.db.QueryRowContext(ctx, query, accountId, key))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -148,22 +542,202 @@ func (s *PostgresJobStore) Get(ctx context.Context, jobId string) (*job.Job, err
 		}
 	}
 
-	return &job, nil
+	return job, nil
+}
+
+// Updates the progress of the job identified by jobId, a nil progress clears it.
+// It's kept apart from [PostgresJobStore.Update] so progress reported while
+// the job runs is never overwritten by other changes.
+//
+// If the update doesn't change any row, a [store.ErrNoRowsAffected] error is returned.
+func (s *PostgresJobStore) UpdateProgress(ctx context.Context, jobId string, progress *job.Progress) error {
+	query := `UPDATE jobs
+	SET progress = $1
+	WHERE id = $2`
+
+	// a nil interface, and not a nil slice, is what gets stored as NULL
+	var progressJSON any
+	if progress != nil {
+		data, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		progressJSON = data
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, progressJSON, jobId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// Sets the heartbeat of the given jobs to now. Jobs that don't exist are ignored.
+func (s *PostgresJobStore) Heartbeat(ctx context.Context, jobIds []string, now time.Time) error {
+	query := `UPDATE jobs
+	SET heartbeat_at = $1
+	WHERE id = ANY($2::uuid[])`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, now, pq.Array(jobIds))
+	return err
 }
 
 // Updates the given [job.Job] in the database.
 // The fields that will be updated are: [job.Job].Task, [job.Job].Payload, [job.Job].RunAt, [job.Job].Status
-// [job.Job].FinishedAt, [job.Job].Retries, [job.Job].MaxRetries and [job.Job].LastError.
+// [job.Job].FinishedAt, [job.Job].Retries, [job.Job].MaxRetries, [job.Job].LastError, [job.Job].LastErrorCode,
+// [job.Job].LeaseID and [job.Job].LeaseExpiresAt.
 // All other changes provided in the struct will be ignored.
 // The SQL Where clause will use the [job.Job].ID to update the record.
 //
 // If the update doesn't change any row, a [store.ErrNoRowsAffected] error is returned.
 func (s *PostgresJobStore) Update(ctx context.Context, job *job.Job) error {
+	return s.update(ctx, job, "")
+}
+
+// UpdateLeased updates the job like Update, as long as it's running under
+// leaseId and, if expiredBefore isn't nil, its lease expired before it.
+//
+// Otherwise, e.g. when the attempt was already finished, a [store.ErrNoRowsAffected] error is returned.
+func (s *PostgresJobStore) UpdateLeased(ctx context.Context, j *job.Job, leaseId string, expiredBefore *time.Time) error {
+	return s.update(ctx, j, `AND lease_id = $13 AND status = $14 AND (lease_expires_at < $15 OR $15::timestamptz IS NULL)`,
+		leaseId, job.StatusRunning, expiredBefore)
+}
+
+// UpdateFromStatus updates the job like Update, as long as it's still in the from status.
+//
+// Otherwise, e.g. when the job was cancelled meanwhile, a [store.ErrNoRowsAffected] error is returned.
+func (s *PostgresJobStore) UpdateFromStatus(ctx context.Context, j *job.Job, from job.Status) error {
+	return s.update(ctx, j, `AND status = $13`, from)
+}
+
+// update sets the fields of the job, on the rows also matching conditions,
+// whose arguments are numbered after the job's.
+func (s *PostgresJobStore) update(ctx context.Context, job *job.Job, conditions string, conditionArgs ...any) error {
+	query := `UPDATE jobs
+	SET task = $1, payload = $2, run_at = $3, status = $4, finished_at = $5, retries = $6, max_retries = $7, last_error = $8,
+	last_error_code = $9, lease_id = $10, lease_expires_at = $11
+	WHERE id = $12 ` + conditions
+
+	args := []any{job.Task, job.Payload, job.RunAt, job.Status, job.FinishedAt, job.Retries, job.MaxRetries, job.LastError,
+		job.LastErrorCode, job.LeaseID, job.LeaseExpiresAt, job.ID}
+	args = append(args, conditionArgs...)
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// Lease marks up to [job.LeaseCriteria].Limit due external jobs of the account as
+// running under the given lease, oldest run_at first, and returns them. Jobs
+// leased concurrently by another request are skipped instead of waited for.
+// The caller records the status change of the jobs returned.
+func (s *PostgresJobStore) Lease(ctx context.Context, criteria *job.LeaseCriteria) ([]*job.Job, error) {
+	query := fmt.Sprintf(`UPDATE jobs
+	SET status = $1, lease_id = $2, lease_expires_at = $3, heartbeat_at = $4, progress = NULL
+	WHERE id IN (
+		SELECT id
+		FROM jobs
+		WHERE account_id = $5
+		AND task = $6
+		AND status = $7
+		AND run_at <= $4
+		AND (expires_at > $4 OR expires_at IS NULL)
+		AND payload->>'name' = ANY($8::text[])
+		ORDER BY run_at
+		LIMIT $9
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING %s`, jobColumns)
+
+	args := []any{job.StatusRunning, criteria.LeaseID, criteria.ExpiresAt, criteria.Now, criteria.AccountID,
+		task.ExternalTask, job.StatusQueued, pq.Array(criteria.Tasks), criteria.Limit}
+
+	return s.queryJobs(ctx, query, args...)
+}
+
+// GetExpiredLeases returns up to limit running jobs whose lease expired before now.
+func (s *PostgresJobStore) GetExpiredLeases(ctx context.Context, now time.Time, limit int) ([]*job.Job, error) {
+	query := fmt.Sprintf(`SELECT %s
+	FROM jobs
+	WHERE status = $1
+	AND lease_expires_at < $2
+	ORDER BY lease_expires_at
+	LIMIT $3`, jobColumns)
+
+	return s.queryJobs(ctx, query, job.StatusRunning, now, limit)
+}
+
+func (s *PostgresJobStore) queryJobs(ctx context.Context, query string, args ...any) ([]*job.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	jobs := []*job.Job{}
+
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// ExtendLease sets the expiry and heartbeat of the running job leased under leaseId.
+//
+// If the job isn't running under that lease, a [store.ErrNoRowsAffected] error is returned.
+func (s *PostgresJobStore) ExtendLease(ctx context.Context, jobId, leaseId string, expiresAt, now time.Time) error {
 	query := `UPDATE jobs
-	SET task = $1, payload = $2, run_at = $3, status = $4, finished_at = $5, retries = $6, max_retries = $7, last_error = $8
-	WHERE id = $9`
+	SET lease_expires_at = $1, heartbeat_at = $2
+	WHERE id = $3
+	AND lease_id = $4
+	AND status = $5`
 
-	args := []any{job.Task, job.Payload, job.RunAt, job.Status, job.FinishedAt, job.Retries, job.MaxRetries, job.LastError, job.ID}
+	args := []any{expiresAt, now, jobId, leaseId, job.StatusRunning}
 
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
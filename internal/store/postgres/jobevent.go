@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+type PostgresJobEventStore struct {
+	*PostgresStore
+}
+
+func newPostgresJobEventStore(postgresStore *PostgresStore) store.JobEventStore {
+	s := &PostgresJobEventStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+// Saves a new [event.Event] in the database.
+// The [event.Event].ID is set with the value generated by the database.
+func (s *PostgresJobEventStore) Save(ctx context.Context, e *event.Event) error {
+	query := `INSERT INTO job_events (job_id, account_id, task, status, previous_status, created_at)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+	RETURNING id`
+
+	args := []any{e.JobID, e.AccountID, e.Task, e.Status, e.PreviousStatus, e.CreatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, args...).Scan(&e.ID)
+}
+
+// Lists up to limit events matching the given [event.Filter], ordered by ID.
+func (s *PostgresJobEventStore) List(ctx context.Context, filter *event.Filter, limit int) ([]*event.Event, error) {
+	query := `SELECT id, job_id, account_id, task, status, COALESCE(previous_status, ''), created_at
+	FROM job_events
+	WHERE account_id = $1
+	AND id > $2
+	AND (task = $3 OR $3 = '')
+	AND (status = $4 OR $4 = '')
+	AND (job_id::text = $5 OR $5 = '')
+	ORDER BY id
+	LIMIT $6`
+
+	args := []any{filter.AccountID, filter.AfterID, filter.Task, filter.Status, filter.JobID, limit}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []*event.Event{}
+
+	for rows.Next() {
+		var e event.Event
+
+		err := rows.Scan(
+			&e.ID,
+			&e.JobID,
+			&e.AccountID,
+			&e.Task,
+			&e.Status,
+			&e.PreviousStatus,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -28,6 +28,10 @@ func (s *PostgresStore) Job() store.JobStore {
 	return newPostgresJobStore(s)
 }
 
+func (s *PostgresStore) JobEvent() store.JobEventStore {
+	return newPostgresJobEventStore(s)
+}
+
 func (s *PostgresStore) Account() store.AccountStore {
 	return newPostgresAccountStore(s)
 }
@@ -40,6 +44,34 @@ func (s *PostgresStore) APIKey() store.APIKeyStore {
 	return newPostgresAPIKeyStore(s)
 }
 
+func (s *PostgresStore) SigningSecret() store.SigningSecretStore {
+	return newPostgresSigningSecretStore(s)
+}
+
+func (s *PostgresStore) Secret() store.SecretStore {
+	return newPostgresSecretStore(s)
+}
+
+func (s *PostgresStore) EmailTemplate() store.EmailTemplateStore {
+	return newPostgresEmailTemplateStore(s)
+}
+
+func (s *PostgresStore) Blob() store.BlobStore {
+	return newPostgresBlobStore(s)
+}
+
+func (s *PostgresStore) SenderIdentity() store.SenderIdentityStore {
+	return newPostgresSenderIdentityStore(s)
+}
+
+func (s *PostgresStore) SMTPConfig() store.SMTPConfigStore {
+	return newPostgresSMTPConfigStore(s)
+}
+
+func (s *PostgresStore) Suppression() store.SuppressionStore {
+	return newPostgresSuppressionStore(s)
+}
+
 func New(cfg *PostgresConfig, logger *slog.Logger) *PostgresStore {
 	store := &PostgresStore{}
 
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+type PostgresSecretStore struct {
+	*PostgresStore
+}
+
+func newPostgresSecretStore(postgresStore *PostgresStore) store.SecretStore {
+	s := &PostgresSecretStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+// Save inserts the secret, or updates the value of the existing one with the same name.
+// The ID and CreatedAt of an updated secret are set with the stored ones.
+func (s *PostgresSecretStore) Save(ctx context.Context, sec *secret.Secret) error {
+	query := `INSERT INTO secrets (id, account_id, name, value, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (account_id, name) DO UPDATE
+	SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	RETURNING id, created_at`
+
+	args := []any{sec.ID, sec.AccountID, sec.Name, sec.Value, sec.CreatedAt, sec.UpdatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, args...).Scan(&sec.ID, &sec.CreatedAt)
+}
+
+func (s *PostgresSecretStore) Get(ctx context.Context, name, accountId string) (*secret.Secret, error) {
+	query := `SELECT id, account_id, name, value, created_at, updated_at
+	FROM secrets
+	WHERE name = $1
+	AND account_id = $2`
+
+	args := []any{name, accountId}
+
+	var sec secret.Secret
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&sec.ID,
+		&sec.AccountID,
+		&sec.Name,
+		&sec.Value,
+		&sec.CreatedAt,
+		&sec.UpdatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &sec, nil
+}
+
+func (s *PostgresSecretStore) GetByAccountId(ctx context.Context, accountId string) ([]*secret.Secret, error) {
+	query := `SELECT id, account_id, name, created_at, updated_at
+	FROM secrets
+	WHERE account_id = $1
+	ORDER BY name`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	secrets := []*secret.Secret{}
+
+	for rows.Next() {
+		var sec secret.Secret
+
+		err := rows.Scan(
+			&sec.ID,
+			&sec.AccountID,
+			&sec.Name,
+			&sec.CreatedAt,
+			&sec.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, &sec)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func (s *PostgresSecretStore) Delete(ctx context.Context, name, accountId string) error {
+	query := `DELETE FROM secrets
+	WHERE name = $1
+	AND account_id = $2`
+
+	args := []any{name, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
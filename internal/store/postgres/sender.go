@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/sender"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+type PostgresSenderIdentityStore struct {
+	*PostgresStore
+}
+
+func newPostgresSenderIdentityStore(postgresStore *PostgresStore) store.SenderIdentityStore {
+	s := &PostgresSenderIdentityStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+func (s *PostgresSenderIdentityStore) Save(ctx context.Context, identity *sender.Identity) error {
+	query := `INSERT INTO sender_identities (id, account_id, address, verification_token, verified_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+
+	args := []any{identity.ID, identity.AccountID, identity.Address, identity.VerificationToken,
+		identity.VerifiedAt, identity.CreatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return duplicateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s *PostgresSenderIdentityStore) Get(ctx context.Context, id, accountId string) (*sender.Identity, error) {
+	query := `SELECT id, account_id, address, verification_token, verified_at, created_at
+	FROM sender_identities
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	var identity sender.Identity
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&identity.ID,
+		&identity.AccountID,
+		&identity.Address,
+		&identity.VerificationToken,
+		&identity.VerifiedAt,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &identity, nil
+}
+
+func (s *PostgresSenderIdentityStore) GetByAccountId(ctx context.Context, accountId string) ([]*sender.Identity, error) {
+	query := `SELECT id, account_id, address, verification_token, verified_at, created_at
+	FROM sender_identities
+	WHERE account_id = $1
+	ORDER BY address`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	identities := []*sender.Identity{}
+
+	for rows.Next() {
+		var identity sender.Identity
+
+		err := rows.Scan(
+			&identity.ID,
+			&identity.AccountID,
+			&identity.Address,
+			&identity.VerificationToken,
+			&identity.VerifiedAt,
+			&identity.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		identities = append(identities, &identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (s *PostgresSenderIdentityStore) MarkVerified(ctx context.Context, id, accountId string, verifiedAt time.Time) error {
+	query := `UPDATE sender_identities
+	SET verified_at = $1
+	WHERE id = $2
+	AND account_id = $3`
+
+	args := []any{verifiedAt, id, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s *PostgresSenderIdentityStore) Delete(ctx context.Context, id, accountId string) error {
+	query := `DELETE FROM sender_identities
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+type PostgresSMTPConfigStore struct {
+	*PostgresStore
+}
+
+func newPostgresSMTPConfigStore(postgresStore *PostgresStore) store.SMTPConfigStore {
+	s := &PostgresSMTPConfigStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+// Save inserts the config, or replaces the existing one of the account.
+// The CreatedAt of a replaced config is set with the stored one.
+func (s *PostgresSMTPConfigStore) Save(ctx context.Context, config *sender.SMTPConfig) error {
+	query := `INSERT INTO smtp_configs (account_id, host, port, username, password, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (account_id) DO UPDATE
+	SET host = EXCLUDED.host, port = EXCLUDED.port, username = EXCLUDED.username,
+	password = EXCLUDED.password, updated_at = EXCLUDED.updated_at
+	RETURNING created_at`
+
+	args := []any{config.AccountID, config.Host, config.Port, config.Username, config.Password,
+		config.CreatedAt, config.UpdatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, args...).Scan(&config.CreatedAt)
+}
+
+func (s *PostgresSMTPConfigStore) Get(ctx context.Context, accountId string) (*sender.SMTPConfig, error) {
+	query := `SELECT account_id, host, port, username, password, created_at, updated_at
+	FROM smtp_configs
+	WHERE account_id = $1`
+
+	var config sender.SMTPConfig
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, accountId).Scan(
+		&config.AccountID,
+		&config.Host,
+		&config.Port,
+		&config.Username,
+		&config.Password,
+		&config.CreatedAt,
+		&config.UpdatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &config, nil
+}
+
+func (s *PostgresSMTPConfigStore) Delete(ctx context.Context, accountId string) error {
+	query := `DELETE FROM smtp_configs
+	WHERE account_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, accountId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/signing"
+	"github.com/ngmmartins/asyncq/internal/store"
+)
+
+type PostgresSigningSecretStore struct {
+	*PostgresStore
+}
+
+func newPostgresSigningSecretStore(postgresStore *PostgresStore) store.SigningSecretStore {
+	s := &PostgresSigningSecretStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+func (s *PostgresSigningSecretStore) Rotate(ctx context.Context, secret *signing.Secret, previousExpiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// secrets already expiring earlier keep their expiry
+	query := `UPDATE signing_secrets
+	SET expires_at = $2
+	WHERE account_id = $1
+	AND (expires_at IS NULL OR expires_at > $2)`
+
+	_, err = tx.ExecContext(ctx, query, secret.AccountID, previousExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO signing_secrets (id, account_id, value, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	args := []any{secret.ID, secret.AccountID, secret.Value, secret.ExpiresAt, secret.CreatedAt}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return tx.Commit()
+}
+
+// GetActive returns the secrets of the account that didn't expire, newest first.
+// The secrets created before they were encrypted have no Value, only their plaintext Secret.
+func (s *PostgresSigningSecretStore) GetActive(ctx context.Context, accountId string, now time.Time) ([]*signing.Secret, error) {
+	query := `SELECT id, account_id, COALESCE(secret, ''), value, expires_at, created_at
+	FROM signing_secrets
+	WHERE account_id = $1
+	AND (expires_at > $2 OR expires_at IS NULL)
+	ORDER BY created_at DESC`
+
+	args := []any{accountId, now}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	secrets := []*signing.Secret{}
+
+	for rows.Next() {
+		var secret signing.Secret
+
+		err := rows.Scan(
+			&secret.ID,
+			&secret.AccountID,
+			&secret.Secret,
+			&secret.Value,
+			&secret.ExpiresAt,
+			&secret.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, &secret)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func (s *PostgresSigningSecretStore) Delete(ctx context.Context, id, accountId string) error {
+	query := `DELETE FROM signing_secrets
+	WHERE id = $1
+	AND account_id = $2`
+
+	args := []any{id, accountId}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
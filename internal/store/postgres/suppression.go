@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/suppression"
+)
+
+type PostgresSuppressionStore struct {
+	*PostgresStore
+}
+
+func newPostgresSuppressionStore(postgresStore *PostgresStore) store.SuppressionStore {
+	s := &PostgresSuppressionStore{
+		PostgresStore: postgresStore,
+	}
+
+	return s
+}
+
+// Save inserts the suppression, or replaces the reason of the existing one of the address.
+// The CreatedAt of a replaced suppression is set with the stored one.
+func (s *PostgresSuppressionStore) Save(ctx context.Context, suppression *suppression.Suppression) error {
+	query := `INSERT INTO email_suppressions (account_id, address, reason, detail, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (account_id, address) DO UPDATE
+	SET reason = EXCLUDED.reason, detail = EXCLUDED.detail
+	RETURNING created_at`
+
+	args := []any{suppression.AccountID, suppression.Address, suppression.Reason, suppression.Detail, suppression.CreatedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query, args...).Scan(&suppression.CreatedAt)
+}
+
+func (s *PostgresSuppressionStore) Get(ctx context.Context, accountId, address string) (*suppression.Suppression, error) {
+	query := `SELECT account_id, address, reason, detail, created_at
+	FROM email_suppressions
+	WHERE account_id = $1
+	AND address = $2`
+
+	args := []any{accountId, address}
+
+	var suppression suppression.Suppression
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&suppression.AccountID,
+		&suppression.Address,
+		&suppression.Reason,
+		&suppression.Detail,
+		&suppression.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, store.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &suppression, nil
+}
+
+func (s *PostgresSuppressionStore) GetByAccountId(ctx context.Context, accountId string) ([]*suppression.Suppression, error) {
+	query := `SELECT account_id, address, reason, detail, created_at
+	FROM email_suppressions
+	WHERE account_id = $1
+	ORDER BY address`
+
+	return s.query(ctx, query, accountId)
+}
+
+func (s *PostgresSuppressionStore) GetByAddresses(ctx context.Context, accountId string, addresses []string) ([]*suppression.Suppression, error) {
+	query := `SELECT account_id, address, reason, detail, created_at
+	FROM email_suppressions
+	WHERE account_id = $1
+	AND address = ANY($2::text[])
+	ORDER BY address`
+
+	return s.query(ctx, query, accountId, pq.Array(addresses))
+}
+
+func (s *PostgresSuppressionStore) query(ctx context.Context, query string, args ...any) ([]*suppression.Suppression, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	suppressions := []*suppression.Suppression{}
+
+	for rows.Next() {
+		var suppression suppression.Suppression
+
+		err := rows.Scan(
+			&suppression.AccountID,
+			&suppression.Address,
+			&suppression.Reason,
+			&suppression.Detail,
+			&suppression.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		suppressions = append(suppressions, &suppression)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suppressions, nil
+}
+
+func (s *PostgresSuppressionStore) Delete(ctx context.Context, accountId, address string) error {
+	query := `DELETE FROM email_suppressions
+	WHERE account_id = $1
+	AND address = $2`
+
+	args := []any{accountId, address}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return store.ErrNoRowsAffected
+	}
+
+	return nil
+}
@@ -7,28 +7,60 @@ import (
 
 	"github.com/ngmmartins/asyncq/internal/account"
 	"github.com/ngmmartins/asyncq/internal/apikey"
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/emailtemplate"
+	"github.com/ngmmartins/asyncq/internal/event"
 	"github.com/ngmmartins/asyncq/internal/job"
 	"github.com/ngmmartins/asyncq/internal/pagination"
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/sender"
+	"github.com/ngmmartins/asyncq/internal/signing"
+	"github.com/ngmmartins/asyncq/internal/suppression"
 	"github.com/ngmmartins/asyncq/internal/token"
 )
 
 var (
-	ErrRecordNotFound = errors.New("record not found")
-	ErrNoRowsAffected = errors.New("no rows affected after query execution")
+	ErrRecordNotFound  = errors.New("record not found")
+	ErrNoRowsAffected  = errors.New("no rows affected after query execution")
+	ErrDuplicateRecord = errors.New("duplicate record")
 )
 
 type Store interface {
 	Job() JobStore
+	JobEvent() JobEventStore
 	Account() AccountStore
 	Token() TokenStore
 	APIKey() APIKeyStore
+	SigningSecret() SigningSecretStore
+	Secret() SecretStore
+	EmailTemplate() EmailTemplateStore
+	Blob() BlobStore
+	SenderIdentity() SenderIdentityStore
+	SMTPConfig() SMTPConfigStore
+	Suppression() SuppressionStore
 }
 
+// JobStore returns ErrDuplicateRecord when saving a job with the idempotency key of another one of its account.
 type JobStore interface {
 	Save(ctx context.Context, job *job.Job) error
 	Search(ctx context.Context, criteria *job.SearchCriteria) ([]*job.Job, *pagination.Metadata, error)
+	Export(ctx context.Context, criteria *job.SearchCriteria, fn func(*job.Job) error) error
+	Purge(ctx context.Context, criteria *job.PurgeCriteria, archive func([]*job.Job) error) (int, error)
 	Get(ctx context.Context, jobId string) (*job.Job, error)
+	GetByIdempotencyKey(ctx context.Context, accountId, key string) (*job.Job, error)
 	Update(ctx context.Context, job *job.Job) error
+	UpdateLeased(ctx context.Context, job *job.Job, leaseId string, expiredBefore *time.Time) error
+	UpdateFromStatus(ctx context.Context, job *job.Job, from job.Status) error
+	UpdateProgress(ctx context.Context, jobId string, progress *job.Progress) error
+	Heartbeat(ctx context.Context, jobIds []string, now time.Time) error
+	Lease(ctx context.Context, criteria *job.LeaseCriteria) ([]*job.Job, error)
+	GetExpiredLeases(ctx context.Context, now time.Time, limit int) ([]*job.Job, error)
+	ExtendLease(ctx context.Context, jobId, leaseId string, expiresAt, now time.Time) error
+}
+
+type JobEventStore interface {
+	Save(ctx context.Context, e *event.Event) error
+	List(ctx context.Context, filter *event.Filter, limit int) ([]*event.Event, error)
 }
 
 type AccountStore interface {
@@ -36,6 +68,7 @@ type AccountStore interface {
 	Get(ctx context.Context, id string) (*account.Account, error)
 	GetByEmail(ctx context.Context, email string) (*account.Account, error)
 	GetForToken(ctx context.Context, hash []byte, scope token.Scope, now time.Time) (*account.Account, error)
+	UpdateJobRetention(ctx context.Context, id string, days *int) error
 }
 
 type TokenStore interface {
@@ -50,3 +83,62 @@ type APIKeyStore interface {
 	GetByAccountId(ctx context.Context, accountId string) ([]*apikey.APIKey, error)
 	Delete(ctx context.Context, id, accountId string) error
 }
+
+type SigningSecretStore interface {
+	// Rotate saves the new secret and makes the account's other active secrets expire at previousExpiresAt at the latest.
+	Rotate(ctx context.Context, secret *signing.Secret, previousExpiresAt time.Time) error
+	GetActive(ctx context.Context, accountId string, now time.Time) ([]*signing.Secret, error)
+	Delete(ctx context.Context, id, accountId string) error
+}
+
+type SecretStore interface {
+	// Save creates the secret or replaces the value of the account's secret with the same name.
+	Save(ctx context.Context, secret *secret.Secret) error
+	Get(ctx context.Context, name, accountId string) (*secret.Secret, error)
+	// GetByAccountId returns the account's secrets without their values.
+	GetByAccountId(ctx context.Context, accountId string) ([]*secret.Secret, error)
+	Delete(ctx context.Context, name, accountId string) error
+}
+
+// EmailTemplateStore returns ErrDuplicateRecord when saving a template with the name of another one of its account.
+type EmailTemplateStore interface {
+	Save(ctx context.Context, template *emailtemplate.Template) error
+	Get(ctx context.Context, id, accountId string) (*emailtemplate.Template, error)
+	GetByAccountId(ctx context.Context, accountId string) ([]*emailtemplate.Template, error)
+	Update(ctx context.Context, template *emailtemplate.Template) error
+	Delete(ctx context.Context, id, accountId string) error
+}
+
+// BlobStore keeps the metadata of the blobs, their content is in a [blob.Store].
+type BlobStore interface {
+	Save(ctx context.Context, blob *blob.Blob) error
+	Get(ctx context.Context, id, accountId string) (*blob.Blob, error)
+	Delete(ctx context.Context, id, accountId string) error
+}
+
+// SenderIdentityStore returns ErrDuplicateRecord when saving an identity with the address of another one of its account.
+type SenderIdentityStore interface {
+	Save(ctx context.Context, identity *sender.Identity) error
+	Get(ctx context.Context, id, accountId string) (*sender.Identity, error)
+	GetByAccountId(ctx context.Context, accountId string) ([]*sender.Identity, error)
+	MarkVerified(ctx context.Context, id, accountId string, verifiedAt time.Time) error
+	Delete(ctx context.Context, id, accountId string) error
+}
+
+type SMTPConfigStore interface {
+	// Save creates the account's SMTP config or replaces the existing one.
+	Save(ctx context.Context, config *sender.SMTPConfig) error
+	Get(ctx context.Context, accountId string) (*sender.SMTPConfig, error)
+	Delete(ctx context.Context, accountId string) error
+}
+
+// SuppressionStore keeps a single suppression per address of an account.
+type SuppressionStore interface {
+	// Save adds the suppression, or replaces the reason of the existing one of the address.
+	Save(ctx context.Context, suppression *suppression.Suppression) error
+	Get(ctx context.Context, accountId, address string) (*suppression.Suppression, error)
+	GetByAccountId(ctx context.Context, accountId string) ([]*suppression.Suppression, error)
+	// GetByAddresses returns the suppressions of the account among the addresses.
+	GetByAddresses(ctx context.Context, accountId string, addresses []string) ([]*suppression.Suppression, error)
+	Delete(ctx context.Context, accountId, address string) error
+}
@@ -0,0 +1,244 @@
+package suppression
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+var ErrInvalidNotification = errors.New("invalid notification")
+
+// Event is an address a bounce or complaint notification says must be suppressed.
+type Event struct {
+	Address string
+	Reason  Reason
+	Detail  string
+}
+
+// ParseNotification returns the addresses to suppress from a bounce or
+// complaint notification, given its content type:
+//   - message/delivery-status: the delivery status of a DSN (RFC 3464)
+//   - message/feedback-report: the report of a complaint (RFC 5965)
+//   - multipart/report: a whole DSN or complaint, with its report as one of the parts
+//   - message/rfc822: an email whose body is a multipart/report
+//   - application/json: an SES notification, optionally wrapped in an SNS message
+//
+// Only failed deliveries with a permanent status are suppressed, delayed and
+// transient failures aren't.
+func ParseNotification(contentType string, body []byte) ([]Event, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: content type: %w", ErrInvalidNotification, err)
+	}
+
+	var events []Event
+	switch mediaType {
+	case "message/delivery-status":
+		events, err = parseDeliveryStatus(bytes.NewReader(body))
+	case "message/feedback-report":
+		events, err = parseFeedbackReport(bytes.NewReader(body))
+	case "multipart/report":
+		events, err = parseReport(bytes.NewReader(body), params["boundary"])
+	case "message/rfc822":
+		events, err = parseReportMessage(bytes.NewReader(body))
+	case "application/json":
+		events, err = parseSESNotification(body)
+	default:
+		return nil, fmt.Errorf("%w: unsupported content type %s", ErrInvalidNotification, mediaType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+
+	return events, nil
+}
+
+func parseReportMessage(r io.Reader) ([]Event, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("content type: %w", err)
+	}
+	if mediaType != "multipart/report" {
+		return nil, fmt.Errorf("the email is a %s instead of a multipart/report", mediaType)
+	}
+
+	return parseReport(msg.Body, params["boundary"])
+}
+
+// parseReport parses the part of the report with the delivery status or the complaint.
+func parseReport(r io.Reader, boundary string) ([]Event, error) {
+	if boundary == "" {
+		return nil, errors.New("the report has no boundary")
+	}
+
+	mr := multipart.NewReader(r, boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, errors.New("the report has no delivery status nor feedback report")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch mediaType {
+		case "message/delivery-status":
+			return parseDeliveryStatus(part)
+		case "message/feedback-report":
+			return parseFeedbackReport(part)
+		}
+	}
+}
+
+// parseDeliveryStatus parses the per-message fields, followed by the fields of
+// each recipient, separated by blank lines.
+func parseDeliveryStatus(r io.Reader) ([]Event, error) {
+	tr := textproto.NewReader(bufio.NewReader(r))
+
+	if _, err := tr.ReadMIMEHeader(); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	events := []Event{}
+	for {
+		fields, err := tr.ReadMIMEHeader()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if len(fields) > 0 {
+			address := typedAddress(fields.Get("Final-Recipient"))
+			if address == "" {
+				address = typedAddress(fields.Get("Original-Recipient"))
+			}
+			status := fields.Get("Status")
+
+			if address != "" && strings.EqualFold(fields.Get("Action"), "failed") && strings.HasPrefix(status, "5") {
+				detail := status
+				if diagnostic := fields.Get("Diagnostic-Code"); diagnostic != "" {
+					detail += " " + typedValue(diagnostic)
+				}
+				events = append(events, Event{Address: address, Reason: ReasonBounce, Detail: detail})
+			}
+		}
+
+		if err == io.EOF {
+			return events, nil
+		}
+	}
+}
+
+func parseFeedbackReport(r io.Reader) ([]Event, error) {
+	tr := textproto.NewReader(bufio.NewReader(r))
+
+	fields, err := tr.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	address := strings.Trim(fields.Get("Original-Rcpt-To"), " <>")
+	if address == "" {
+		return nil, errors.New("the feedback report has no Original-Rcpt-To")
+	}
+
+	return []Event{{Address: address, Reason: ReasonComplaint, Detail: fields.Get("Feedback-Type")}}, nil
+}
+
+// typedAddress returns the address of a field such as "rfc822; john@example.com".
+func typedAddress(field string) string {
+	addressType, address, found := strings.Cut(field, ";")
+	if !found || !strings.EqualFold(strings.TrimSpace(addressType), "rfc822") {
+		return ""
+	}
+	return strings.Trim(address, " <>")
+}
+
+// typedValue returns the value of a field such as "smtp; 550 5.1.1 no such user".
+func typedValue(field string) string {
+	_, value, found := strings.Cut(field, ";")
+	if !found {
+		return field
+	}
+	return strings.TrimSpace(value)
+}
+
+type snsMessage struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	Bounce           *struct {
+		BounceType        string `json:"bounceType"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			Status         string `json:"status"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+func parseSESNotification(body []byte) ([]Event, error) {
+	var sns snsMessage
+	if err := json.Unmarshal(body, &sns); err != nil {
+		return nil, err
+	}
+	if sns.Type == "Notification" {
+		body = []byte(sns.Message)
+	}
+
+	var notification sesNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, err
+	}
+
+	events := []Event{}
+	switch notification.NotificationType {
+	case "Bounce":
+		if notification.Bounce == nil {
+			return nil, errors.New("the bounce notification has no bounce")
+		}
+		// transient bounces, e.g. a full mailbox, may succeed later
+		if notification.Bounce.BounceType != "Permanent" {
+			return events, nil
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			detail := strings.TrimSpace(recipient.Status + " " + recipient.DiagnosticCode)
+			events = append(events, Event{Address: recipient.EmailAddress, Reason: ReasonBounce, Detail: detail})
+		}
+	case "Complaint":
+		if notification.Complaint == nil {
+			return nil, errors.New("the complaint notification has no complaint")
+		}
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			events = append(events, Event{Address: recipient.EmailAddress, Reason: ReasonComplaint,
+				Detail: notification.Complaint.ComplaintFeedbackType})
+		}
+	case "Delivery":
+	default:
+		return nil, fmt.Errorf("unknown notification type %q", notification.NotificationType)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,63 @@
+package suppression
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type Reason string
+
+// add to ReasonList when adding here a new const
+const (
+	ReasonBounce      Reason = "bounce"      // The address hard bounced
+	ReasonComplaint   Reason = "complaint"   // The recipient reported an email as spam
+	ReasonUnsubscribe Reason = "unsubscribe" // The recipient asked not to receive more emails
+	ReasonManual      Reason = "manual"      // Added by the account for another reason
+)
+
+var ReasonList = []Reason{ReasonBounce, ReasonComplaint, ReasonUnsubscribe, ReasonManual}
+
+const (
+	MaxAddressLength = 254
+	MaxDetailLength  = 1024
+)
+
+// Suppression is an address the emails of an account aren't sent to anymore.
+type Suppression struct {
+	AccountID string `json:"account_id"`
+	Address   string `json:"address"`
+	Reason    Reason `json:"reason"`
+	// Why the address was suppressed, e.g. the status of the bounce
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSuppression(accountId, address string, reason Reason, detail string) *Suppression {
+	return &Suppression{
+		AccountID: accountId,
+		Address:   NormalizeAddress(address),
+		Reason:    reason,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NormalizeAddress returns the address the way suppressions are stored, so
+// the same mailbox is matched however it's written.
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// ValidAddress reports whether the address is a plain email address, without a display name.
+func ValidAddress(address string) bool {
+	parsed, err := mail.ParseAddress(address)
+	return err == nil && parsed.Address == address
+}
+
+type Request struct {
+	Address string `json:"address"`
+	// ReasonManual if not set
+	Reason Reason `json:"reason"`
+	Detail string `json:"detail"`
+}
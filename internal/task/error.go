@@ -0,0 +1,35 @@
+package task
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrSuppressed is returned by executors that didn't run the job because it
+// targets a suppressed address. The job finishes as Suppressed, without retries.
+var ErrSuppressed = errors.New("suppressed")
+
+// ExecutionError is returned by executors to tell the worker how a failed job
+// should be retried. Other errors are retried with the job's retry delay.
+type ExecutionError struct {
+	Err error
+	// The job fails right away, without using its remaining retries
+	Permanent bool
+	// Minimum time to wait before the next attempt
+	RetryAfter time.Duration
+	// Classification of the failure stored on the job, e.g. invalid_recipient
+	Code string
+}
+
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent marks err as a failure that retrying won't fix.
+func Permanent(err error) error {
+	return &ExecutionError{Err: err, Permanent: true}
+}
@@ -1,8 +1,19 @@
 package task
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/ngmmartins/asyncq/internal/blob"
 	"github.com/ngmmartins/asyncq/internal/validator"
 )
 
@@ -11,37 +22,297 @@ type Task string
 const (
 	WebhookTask   Task = "webhook"
 	SendEmailTask Task = "send_email"
+	// ExternalTask jobs aren't run by the worker, they are leased by external consumers
+	ExternalTask Task = "external"
 )
 
-// list of supported tasks
-var Tasks = []Task{WebhookTask, SendEmailTask}
-
 type WebhookPayload struct {
 	URL     string            `json:"url"`
 	Method  string            `json:"method"`
 	Headers map[string]string `json:"headers,omitempty"`
 	Body    json.RawMessage   `json:"body,omitempty"`
+	// Response status codes the call succeeds with. Any 2xx when empty
+	SuccessStatusCodes []int        `json:"success_status_codes,omitempty"`
+	Auth               *WebhookAuth `json:"auth,omitempty"`
+	// Added to the query of the URL
+	Query map[string]string `json:"query,omitempty"`
+	// Seconds the call can take, DefaultWebhookTimeoutSec when not set
+	TimeoutSec *int `json:"timeout_sec,omitempty"`
+	// Redirects are followed unless false
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+	// How many redirects are followed, MaxWebhookRedirects when not set
+	MaxRedirects *int `json:"max_redirects,omitempty"`
+	// Name of the account secret with the PEM encoded client certificate and its private key, used for mTLS
+	ClientCertRef string `json:"client_cert_ref,omitempty"`
+}
+
+const (
+	DefaultWebhookTimeoutSec = 30
+	MaxWebhookTimeoutSec     = 300
+	MaxWebhookRedirects      = 10
+)
+
+// Timeout returns how long the webhook call can take.
+func (p *WebhookPayload) Timeout() time.Duration {
+	if p.TimeoutSec == nil {
+		return DefaultWebhookTimeoutSec * time.Second
+	}
+	return time.Duration(*p.TimeoutSec) * time.Second
+}
+
+// RedirectLimit returns how many redirects the webhook call follows.
+func (p *WebhookPayload) RedirectLimit() int {
+	switch {
+	case p.FollowRedirects != nil && !*p.FollowRedirects:
+		return 0
+	case p.MaxRedirects != nil:
+		return *p.MaxRedirects
+	default:
+		return MaxWebhookRedirects
+	}
+}
+
+type WebhookAuthType string
+
+const (
+	WebhookAuthBasic                   WebhookAuthType = "basic"
+	WebhookAuthBearer                  WebhookAuthType = "bearer"
+	WebhookAuthOAuth2ClientCredentials WebhookAuthType = "oauth2_client_credentials"
+)
+
+var WebhookAuthTypes = []WebhookAuthType{WebhookAuthBasic, WebhookAuthBearer, WebhookAuthOAuth2ClientCredentials}
+
+// WebhookAuth authenticates the webhook call. Credentials are given as the name
+// of an account secret (the *_ref fields), so they are never stored in the job.
+type WebhookAuth struct {
+	Type WebhookAuthType `json:"type"`
+	// basic
+	Username    string `json:"username,omitempty"`
+	PasswordRef string `json:"password_ref,omitempty"`
+	// bearer
+	TokenRef string `json:"token_ref,omitempty"`
+	// oauth2_client_credentials
+	TokenURL        string   `json:"token_url,omitempty"`
+	ClientID        string   `json:"client_id,omitempty"`
+	ClientSecretRef string   `json:"client_secret_ref,omitempty"`
+	Scopes          []string `json:"scopes,omitempty"`
 }
 
+// IsSuccess reports whether the webhook call succeeded with the given response status code.
+func (p *WebhookPayload) IsSuccess(statusCode int) bool {
+	if len(p.SuccessStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode <= 299
+	}
+	return slices.Contains(p.SuccessStatusCodes, statusCode)
+}
+
+// methods and URL schemes webhooks can be called with
+var (
+	WebhookMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	WebhookSchemes = []string{"http", "https"}
+)
+
 func ValidateWebhookPayload(v *validator.Validator, p *WebhookPayload) {
 	v.CheckRequired(p.URL != "", "payload.url")
 	v.CheckRequired(p.Method != "", "payload.method")
+
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		v.Check(err == nil && slices.Contains(WebhookSchemes, u.Scheme) && u.Host != "", "payload.url", "must be an absolute http or https URL")
+	}
+	if p.Method != "" {
+		v.Check(slices.Contains(WebhookMethods, p.Method), "payload.method", fmt.Sprintf("must be one of %s", strings.Join(WebhookMethods, ", ")))
+	}
+
+	for name, value := range p.Headers {
+		v.Check(name != "" && !strings.ContainsAny(name, " :\r\n"), "payload.headers", fmt.Sprintf("invalid header name %q", name))
+		v.Check(!strings.ContainsAny(value, "\r\n"), "payload.headers", fmt.Sprintf("invalid value for header %q", name))
+	}
+
+	for _, code := range p.SuccessStatusCodes {
+		v.Check(code >= 100 && code <= 599, "payload.success_status_codes", "must only contain status codes between 100 and 599")
+	}
+
+	for name := range p.Query {
+		v.Check(name != "", "payload.query", "must not have empty parameter names")
+	}
+
+	if p.TimeoutSec != nil {
+		v.Check(*p.TimeoutSec >= 1 && *p.TimeoutSec <= MaxWebhookTimeoutSec, "payload.timeout_sec", fmt.Sprintf("must be between 1 and %d", MaxWebhookTimeoutSec))
+	}
+	if p.MaxRedirects != nil {
+		v.Check(*p.MaxRedirects >= 0 && *p.MaxRedirects <= MaxWebhookRedirects, "payload.max_redirects", fmt.Sprintf("must be between 0 and %d", MaxWebhookRedirects))
+	}
+
+	if p.Auth != nil {
+		validateWebhookAuth(v, p.Auth)
+	}
 	// TODO other checks
 }
 
+func validateWebhookAuth(v *validator.Validator, a *WebhookAuth) {
+	v.Check(slices.Contains(WebhookAuthTypes, a.Type), "payload.auth.type", "must be basic, bearer or oauth2_client_credentials")
+
+	switch a.Type {
+	case WebhookAuthBasic:
+		v.CheckRequired(a.Username != "", "payload.auth.username")
+		v.CheckRequired(a.PasswordRef != "", "payload.auth.password_ref")
+	case WebhookAuthBearer:
+		v.CheckRequired(a.TokenRef != "", "payload.auth.token_ref")
+	case WebhookAuthOAuth2ClientCredentials:
+		v.CheckRequired(a.TokenURL != "", "payload.auth.token_url")
+		v.CheckRequired(a.ClientID != "", "payload.auth.client_id")
+		v.CheckRequired(a.ClientSecretRef != "", "payload.auth.client_secret_ref")
+
+		if a.TokenURL != "" {
+			u, err := url.Parse(a.TokenURL)
+			v.Check(err == nil && slices.Contains(WebhookSchemes, u.Scheme) && u.Host != "", "payload.auth.token_url", "must be an absolute http or https URL")
+		}
+	}
+}
+
 type SendEmailPayload struct {
-	From    string   `json:"from"`
-	To      []string `json:"to"`
-	Cc      []string `json:"cc,omitempty"`
-	Bcc     []string `json:"bcc,omitempty"`
-	Subject string   `json:"subject"`
-	Body    string   `json:"body"`
+	From     string            `json:"from"`
+	To       []string          `json:"to"`
+	Cc       []string          `json:"cc,omitempty"`
+	Bcc      []string          `json:"bcc,omitempty"`
+	ReplyTo  string            `json:"reply_to,omitempty"`
+	Subject  string            `json:"subject"`
+	Body     string            `json:"body"`
+	HTMLBody string            `json:"html_body,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	// The subject and bodies are rendered from the account's template with the
+	// given data when the job is created
+	TemplateID  string            `json:"template_id,omitempty"`
+	Data        map[string]any    `json:"data,omitempty"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
+}
+
+// EmailAttachment is a file attached to the email, either given inline as
+// base64 encoded content or referencing a blob uploaded by the account.
+type EmailAttachment struct {
+	// Taken from the blob when not set
+	Filename string `json:"filename,omitempty"`
+	// Taken from the blob when not set, or application/octet-stream for inline content
+	ContentType string `json:"content_type,omitempty"`
+	Content     string `json:"content,omitempty"`
+	BlobID      string `json:"blob_id,omitempty"`
 }
 
+const (
+	// MaxEmailRecipients is the combined number of to, cc and bcc addresses
+	MaxEmailRecipients = 50
+	// the line length limit of RFC 5322, which the subject must fit in
+	MaxEmailSubjectLength = 998
+	// MaxEmailBodySize is the combined size of the text and HTML bodies
+	MaxEmailBodySize          = 1 << 20
+	MaxEmailHeaders           = 20
+	MaxEmailHeaderValueLength = 998
+
+	MaxEmailAttachments = 10
+	// MaxInlineAttachmentsSize is the combined size of the decoded inline
+	// attachments, larger files must be uploaded as blobs
+	MaxInlineAttachmentsSize = 2 << 20
+)
+
+// headers set from the payload fields or by the sender, that custom headers can't replace
+var reservedEmailHeaders = []string{"from", "to", "cc", "bcc", "reply-to", "subject", "date", "message-id",
+	"mime-version", "content-type", "content-transfer-encoding"}
+
 func ValidateSendEmailPayload(v *validator.Validator, p *SendEmailPayload) {
 	v.CheckRequired(p.From != "", "payload.from")
+	v.Check(p.From == "" || validator.IsEmailAddress(p.From), "payload.from", "must be a valid email address")
+	v.Check(p.ReplyTo == "" || validator.IsEmailAddress(p.ReplyTo), "payload.reply_to", "must be a valid email address")
+
 	v.CheckRequired(len(p.To) > 0, "payload.to")
-	v.CheckRequired(p.Subject != "", "payload.subject")
-	// TODO other checks
-	// TODO validate emails here (to, cc, bcc)
+	validateEmailRecipients(v, "payload.to", p.To)
+	validateEmailRecipients(v, "payload.cc", p.Cc)
+	validateEmailRecipients(v, "payload.bcc", p.Bcc)
+	v.Check(len(p.To)+len(p.Cc)+len(p.Bcc) <= MaxEmailRecipients, "payload.to", fmt.Sprintf("must not have more than %d recipients, including cc and bcc", MaxEmailRecipients))
+
+	if p.TemplateID != "" {
+		v.Check(uuid.Validate(p.TemplateID) == nil, "payload.template_id", "must be a valid id")
+		v.Check(p.Subject == "", "payload.subject", "must not be set with template_id")
+		v.Check(p.Body == "" && p.HTMLBody == "", "payload.body", "must not be set with template_id")
+	} else {
+		v.CheckRequired(p.Subject != "", "payload.subject")
+		v.Check(p.Data == nil, "payload.data", "must only be set with template_id")
+		ValidateEmailContent(v, p)
+	}
+
+	v.Check(len(p.Headers) <= MaxEmailHeaders, "payload.headers", fmt.Sprintf("must not have more than %d headers", MaxEmailHeaders))
+	for name, value := range p.Headers {
+		v.Check(name != "" && !strings.ContainsAny(name, " :\r\n"), "payload.headers", fmt.Sprintf("invalid header name %q", name))
+		v.Check(!slices.Contains(reservedEmailHeaders, strings.ToLower(name)), "payload.headers", fmt.Sprintf("header %q can't be set", name))
+		v.Check(!strings.ContainsAny(value, "\r\n"), "payload.headers", fmt.Sprintf("invalid value for header %q", name))
+		v.Check(len(value) <= MaxEmailHeaderValueLength, "payload.headers", fmt.Sprintf("value of header %q must not be more than %d bytes long", name, MaxEmailHeaderValueLength))
+	}
+
+	validateEmailAttachments(v, p.Attachments)
+}
+
+// ValidateEmailContent checks the subject and bodies of the email, which are
+// rendered from the template of templated emails before being checked.
+func ValidateEmailContent(v *validator.Validator, p *SendEmailPayload) {
+	v.Check(!strings.ContainsAny(p.Subject, "\r\n"), "payload.subject", "must not contain line breaks")
+	v.Check(len(p.Subject) <= MaxEmailSubjectLength, "payload.subject", fmt.Sprintf("must not be more than %d bytes long", MaxEmailSubjectLength))
+	v.Check(len(p.Body)+len(p.HTMLBody) <= MaxEmailBodySize, "payload.body", fmt.Sprintf("must not be more than %d bytes long, including html_body", MaxEmailBodySize))
+}
+
+func validateEmailRecipients(v *validator.Validator, key string, addresses []string) {
+	for i, address := range addresses {
+		v.Check(validator.IsEmailAddress(address), fmt.Sprintf("%s[%d]", key, i), "must be a valid email address")
+	}
+}
+
+func validateEmailAttachments(v *validator.Validator, attachments []EmailAttachment) {
+	v.Check(len(attachments) <= MaxEmailAttachments, "payload.attachments", fmt.Sprintf("must not have more than %d attachments", MaxEmailAttachments))
+
+	var inlineSize int
+	for i, a := range attachments {
+		key := fmt.Sprintf("payload.attachments[%d]", i)
+
+		v.Check((a.Content == "") != (a.BlobID == ""), key, "must have either content or blob_id")
+		v.Check(len(a.Filename) <= blob.MaxFilenameLength, key+".filename", fmt.Sprintf("must not be more than %d bytes long", blob.MaxFilenameLength))
+		v.Check(!strings.ContainsAny(a.Filename, "/\\\r\n"), key+".filename", "must not contain path separators or line breaks")
+
+		if a.ContentType != "" {
+			_, _, err := mime.ParseMediaType(a.ContentType)
+			v.Check(err == nil, key+".content_type", "must be a valid media type")
+		}
+
+		if a.BlobID != "" {
+			v.Check(uuid.Validate(a.BlobID) == nil, key+".blob_id", "must be a valid id")
+		}
+
+		if a.Content != "" {
+			v.CheckRequired(a.Filename != "", key+".filename")
+
+			content, err := base64.StdEncoding.DecodeString(a.Content)
+			v.Check(err == nil, key+".content", "must be base64 encoded")
+			inlineSize += len(content)
+		}
+	}
+
+	v.Check(inlineSize <= MaxInlineAttachmentsSize, "payload.attachments", fmt.Sprintf("inline content must not be more than %d bytes long, upload larger files as blobs", MaxInlineAttachmentsSize))
+}
+
+// ExternalPayload is the payload of a job run by an external consumer, which
+// leases the jobs of the task names it runs.
+type ExternalPayload struct {
+	// The task the consumer runs, e.g. resize_image
+	Name string `json:"name"`
+	// Passed as is to the consumer
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+const MaxExternalDataSize = 64 << 10
+
+// ExternalTaskNameRX matches the names of external tasks.
+var ExternalTaskNameRX = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,63}$`)
+
+func ValidateExternalPayload(v *validator.Validator, p *ExternalPayload) {
+	v.CheckRequired(p.Name != "", "payload.name")
+	v.Check(p.Name == "" || ExternalTaskNameRX.MatchString(p.Name), "payload.name", "must be up to 64 lowercase letters, digits, underscores, dots and dashes, starting with a letter")
+	v.Check(len(p.Data) <= MaxExternalDataSize, "payload.data", fmt.Sprintf("must not be more than %d bytes long", MaxExternalDataSize))
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"net/mail"
 	"strings"
 )
 
@@ -38,6 +39,17 @@ func (v *Validator) CheckRequired(ok bool, key string) {
 	v.Check(ok, key, "required field")
 }
 
+// IsEmailAddress reports whether the value is a single RFC 5322 address, with or
+// without a display name, e.g. "john@example.com" or "John <john@example.com>".
+// Line breaks are rejected, so the value can't inject headers.
+func IsEmailAddress(value string) bool {
+	if strings.ContainsAny(value, "\r\n") {
+		return false
+	}
+	_, err := mail.ParseAddress(value)
+	return err == nil
+}
+
 type ValidationError struct {
 	Errors map[string]string
 }
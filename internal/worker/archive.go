@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+)
+
+// FileArchiver writes purged jobs to gzip compressed NDJSON files, one file per batch.
+type FileArchiver struct {
+	dir string
+}
+
+func NewFileArchiver(dir string) *FileArchiver {
+	return &FileArchiver{dir: dir}
+}
+
+// Archive writes the jobs to a new file in the archive directory.
+// The file is written under a temporary name and only renamed once it's
+// synced to disk, so a file with the final name is always complete.
+func (a *FileArchiver) Archive(jobs []*job.Job) error {
+	tmp, err := os.CreateTemp(a.dir, ".jobs-*.tmp")
+	if err != nil {
+		return err
+	}
+	// no-op once the file was renamed
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	gz := gzip.NewWriter(tmp)
+	enc := json.NewEncoder(gz)
+
+	for _, j := range jobs {
+		err = enc.Encode(j)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	// the first job id keeps names unique between batches archived in the same second
+	name := fmt.Sprintf("jobs-%s-%s.ndjson.gz", time.Now().UTC().Format("20060102T150405Z"), jobs[0].ID)
+
+	return os.Rename(tmp.Name(), filepath.Join(a.dir, name))
+}
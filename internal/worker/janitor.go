@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/service"
+)
+
+type RetentionMode string
+
+const (
+	// Purged jobs are deleted
+	RetentionModeDelete RetentionMode = "delete"
+	// Purged jobs are moved to the jobs_archive table
+	RetentionModeTable RetentionMode = "table"
+	// Purged jobs are written to compressed NDJSON files before being deleted
+	RetentionModeFiles RetentionMode = "files"
+)
+
+var RetentionModes = []RetentionMode{RetentionModeDelete, RetentionModeTable, RetentionModeFiles}
+
+type RetentionConfig struct {
+	// Days finished jobs are kept for, unless their account overrides it. 0 disables it
+	DefaultDays int
+	Mode        RetentionMode
+	// Where the archive files are written to, when using RetentionModeFiles
+	ArchiveDir string
+	// How often the janitor runs
+	Interval time.Duration
+	// How many jobs are purged per transaction
+	BatchSize int
+}
+
+// Published at /debug/vars when the worker serves metrics
+var janitorMetrics = expvar.NewMap("janitor")
+
+// Janitor periodically purges the finished jobs that are past their retention.
+type Janitor struct {
+	jobService *service.JobService
+	config     RetentionConfig
+	archiver   *FileArchiver
+	logger     *slog.Logger
+}
+
+func NewJanitor(logger *slog.Logger, jobService *service.JobService, config RetentionConfig) *Janitor {
+	j := &Janitor{
+		jobService: jobService,
+		config:     config,
+		logger:     logger,
+	}
+
+	if config.Mode == RetentionModeFiles {
+		j.archiver = NewFileArchiver(config.ArchiveDir)
+	}
+
+	return j
+}
+
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+	j.logger.Info("janitor started", "interval", j.config.Interval, "mode", j.config.Mode, "defaultDays", j.config.DefaultDays)
+
+	for {
+		j.purge(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			j.logger.Info("Janitor stopped")
+			return
+		}
+	}
+}
+
+// purge deletes batches of jobs until there are no more past their retention.
+func (j *Janitor) purge(ctx context.Context) {
+	janitorMetrics.Add("runs", 1)
+
+	criteria := &job.PurgeCriteria{
+		Statuses:             job.FinalStatusList,
+		DefaultRetentionDays: j.config.DefaultDays,
+		Now:                  time.Now(),
+		Limit:                j.config.BatchSize,
+		ArchiveToTable:       j.config.Mode == RetentionModeTable,
+	}
+
+	var archive func([]*job.Job) error
+	if j.archiver != nil {
+		archive = j.archiver.Archive
+	}
+
+	total := 0
+
+	for ctx.Err() == nil {
+		purged, err := j.jobService.PurgeJobs(ctx, criteria, archive)
+		if err != nil {
+			janitorMetrics.Add("errors", 1)
+			j.logger.Error("Error purging jobs", "purgedSoFar", total, "err", err.Error())
+			return
+		}
+
+		total += purged
+		janitorMetrics.Add("jobs_purged", int64(purged))
+		if j.config.Mode != RetentionModeDelete {
+			janitorMetrics.Add("jobs_archived", int64(purged))
+		}
+
+		if purged < criteria.Limit {
+			break
+		}
+	}
+
+	j.logger.Debug("purged jobs past their retention", "count", total)
+}
@@ -1,7 +1,13 @@
 package worker
 
-import "github.com/ngmmartins/asyncq/internal/job"
+import (
+	"context"
 
+	"github.com/ngmmartins/asyncq/internal/job"
+)
+
+// TaskExecutor runs the task of a job.
+// Executors can report the job progress with [job.ReportProgress] on the given context.
 type TaskExecutor interface {
-	Execute(job.Job) error
+	Execute(ctx context.Context, j job.Job) error
 }
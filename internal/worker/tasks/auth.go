@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/task"
+)
+
+// authorize sets the credentials of the payload auth on the request. It returns
+// a function that drops the cached credentials, to be called when the target
+// rejects them, or nil if they aren't cached.
+func (e *WebhookExecutor) authorize(ctx context.Context, req *http.Request, j *job.Job, auth *task.WebhookAuth) (func(), error) {
+	switch auth.Type {
+	case task.WebhookAuthBasic:
+		password, err := e.secretValue(ctx, j, auth.PasswordRef)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(auth.Username, password)
+
+	case task.WebhookAuthBearer:
+		token, err := e.secretValue(ctx, j, auth.TokenRef)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+	case task.WebhookAuthOAuth2ClientCredentials:
+		clientSecret, err := e.secretValue(ctx, j, auth.ClientSecretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := e.tokens.Token(ctx, auth, clientSecret)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return func() { e.tokens.Invalidate(auth, clientSecret) }, nil
+
+	default:
+		return nil, task.Permanent(fmt.Errorf("unsupported webhook auth type %q", auth.Type))
+	}
+
+	return nil, nil
+}
+
+// secretValue returns the value of the job's account secret with the given name.
+func (e *WebhookExecutor) secretValue(ctx context.Context, j *job.Job, name string) (string, error) {
+	if j.AccountID == "" {
+		return "", task.Permanent(errors.New("jobs without an account can't use secrets"))
+	}
+
+	value, err := e.secrets.GetSecretValue(ctx, name, j.AccountID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			return "", task.Permanent(fmt.Errorf("secret %q not found", name))
+		}
+		return "", err
+	}
+
+	return value, nil
+}
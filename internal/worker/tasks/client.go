@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"net/http"
+	"sync"
+
+	"github.com/ngmmartins/asyncq/internal/task"
+)
+
+// how many client certificates have their transport kept, past it they are all dropped
+const maxCertClients = 100
+
+type redirectLimitContextKey struct{}
+
+// contextSetRedirectLimit returns a context that makes the requests made with it follow up to limit redirects.
+func contextSetRedirectLimit(ctx context.Context, limit int) context.Context {
+	return context.WithValue(ctx, redirectLimitContextKey{}, limit)
+}
+
+// checkRedirect stops following redirects past the limit of the request context.
+// The last redirect response is then returned instead of an error.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	limit, ok := req.Context().Value(redirectLimitContextKey{}).(int)
+	if !ok {
+		limit = task.MaxWebhookRedirects
+	}
+
+	if len(via) > limit {
+		return http.ErrUseLastResponse
+	}
+	return nil
+}
+
+// webhookClients holds the HTTP clients webhooks are called with. They all
+// share the guarded transport, so connections are reused between calls, except
+// the ones using a client certificate, which get a transport per certificate.
+type webhookClients struct {
+	transport *http.Transport
+	client    *http.Client
+
+	mu          sync.Mutex
+	certClients map[[sha256.Size]byte]*http.Client
+}
+
+func newWebhookClients(guard *AddressGuard) *webhookClients {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would make the guard check the proxy address instead of the target one
+	transport.Proxy = nil
+	transport.DialContext = guard.Dialer().DialContext
+
+	return &webhookClients{
+		transport:   transport,
+		client:      &http.Client{Transport: transport, CheckRedirect: checkRedirect},
+		certClients: map[[sha256.Size]byte]*http.Client{},
+	}
+}
+
+// Default returns the client for calls without a client certificate.
+func (c *webhookClients) Default() *http.Client {
+	return c.client
+}
+
+// WithCertificate returns the client for calls authenticated with the PEM
+// encoded certificate chain and private key.
+func (c *webhookClients) WithCertificate(certPEM []byte) (*http.Client, error) {
+	key := sha256.Sum256(certPEM)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.certClients[key]; ok {
+		return client, nil
+	}
+
+	// the key is read from the same PEM, after the certificates
+	cert, err := tls.X509KeyPair(certPEM, certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.certClients) >= maxCertClients {
+		for k, client := range c.certClients {
+			client.CloseIdleConnections()
+			delete(c.certClients, k)
+		}
+	}
+
+	transport := c.transport.Clone()
+	transport.TLSClientConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	client := &http.Client{Transport: transport, CheckRedirect: checkRedirect}
+	c.certClients[key] = client
+
+	return client, nil
+}
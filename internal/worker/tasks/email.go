@@ -2,38 +2,253 @@ package tasks
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/ngmmartins/asyncq/internal/blob"
 	"github.com/ngmmartins/asyncq/internal/email"
 	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/service"
 	"github.com/ngmmartins/asyncq/internal/task"
 )
 
+const (
+	// how many accounts have their SMTP sender kept, past it they are all dropped
+	maxAccountSenders = 100
+	// how long at least until an email the server rate limited is sent again
+	rateLimitedRetryAfter = time.Minute
+)
+
+type accountSender struct {
+	sender *email.SMTPSender
+	// when the config the sender was created with was last updated
+	updatedAt time.Time
+}
+
 type SendEmailExecutor struct {
 	logger      *slog.Logger
 	emailSender email.EmailSender
+	blobService *service.BlobService
+	smtpConfigs *service.SMTPConfigService
+	// emails to suppressed addresses aren't sent
+	suppressions *service.SuppressionService
+	// the SMTP servers of the accounts are dialed through it
+	guard *AddressGuard
+
+	// the senders of the accounts SMTP servers, so their connections are reused between jobs
+	mu             sync.Mutex
+	accountSenders map[string]accountSender
 }
 
-func NewSendEmailExecutor(logger *slog.Logger, emailSender email.EmailSender) *SendEmailExecutor {
-	return &SendEmailExecutor{logger: logger, emailSender: emailSender}
+func NewSendEmailExecutor(logger *slog.Logger, emailSender email.EmailSender, blobService *service.BlobService,
+	smtpConfigs *service.SMTPConfigService, suppressions *service.SuppressionService, guard *AddressGuard) *SendEmailExecutor {
+	return &SendEmailExecutor{
+		logger:       logger,
+		emailSender:  emailSender,
+		blobService:  blobService,
+		smtpConfigs:  smtpConfigs,
+		suppressions: suppressions,
+		guard:        guard,
+
+		accountSenders: map[string]accountSender{},
+	}
 }
 
-// TODO
-func (e *SendEmailExecutor) Execute(j job.Job) error {
+// Execute sends the email of the job, through the SMTP server of its account if
+// it has one. A payload that can't be decoded fails the job permanently, like the
+// send errors classified as permanent, see [email.Error], and an email to a
+// suppressed recipient isn't sent, see [task.ErrSuppressed].
+func (e *SendEmailExecutor) Execute(ctx context.Context, j job.Job) error {
 	var payload task.SendEmailPayload
 	if err := json.Unmarshal(j.Payload, &payload); err != nil {
-		return fmt.Errorf("invalid email payload: %w", err)
-	}
-
-	return e.emailSender.Send(
-		context.Background(),
-		payload.To,
-		payload.Cc,
-		payload.Bcc,
-		payload.From,
-		payload.Subject,
-		payload.Body,
-	)
+		return task.Permanent(fmt.Errorf("invalid email payload: %w", err))
+	}
+
+	err := e.checkSuppressions(ctx, j.AccountID, &payload)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := e.attachments(ctx, j.AccountID, payload.Attachments)
+	if err != nil {
+		return err
+	}
+
+	emailSender, err := e.sender(ctx, j.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// the subject and bodies of templated emails were rendered into the payload when the job was created
+	err = emailSender.Send(ctx, &email.Message{
+		From:        payload.From,
+		To:          payload.To,
+		Cc:          payload.Cc,
+		Bcc:         payload.Bcc,
+		ReplyTo:     payload.ReplyTo,
+		Subject:     payload.Subject,
+		TextBody:    payload.Body,
+		HTMLBody:    payload.HTMLBody,
+		Headers:     payload.Headers,
+		Attachments: attachments,
+	})
+	if err != nil {
+		return sendError(err)
+	}
+
+	return nil
+}
+
+// sendError turns the error of an email that couldn't be sent into the one of
+// the job, carrying its classification.
+func sendError(err error) error {
+	if errors.Is(err, ErrForbiddenAddress) {
+		return task.Permanent(err)
+	}
+
+	var emailErr *email.Error
+	if !errors.As(err, &emailErr) {
+		return err
+	}
+
+	execErr := &task.ExecutionError{Err: err, Permanent: emailErr.Permanent, Code: string(emailErr.Reason)}
+	if emailErr.Reason == email.ReasonRateLimited {
+		execErr.RetryAfter = rateLimitedRetryAfter
+	}
+	return execErr
+}
+
+// checkSuppressions returns a [task.ErrSuppressed] error if any recipient of the email is suppressed,
+// so none of them is sent it.
+func (e *SendEmailExecutor) checkSuppressions(ctx context.Context, accountId string, payload *task.SendEmailPayload) error {
+	recipients := slices.Concat(payload.To, payload.Cc, payload.Bcc)
+
+	suppressions, err := e.suppressions.GetSuppressedAddresses(ctx, accountId, recipients)
+	if err != nil {
+		return err
+	}
+
+	if len(suppressions) == 0 {
+		return nil
+	}
+
+	suppressed := make([]string, 0, len(suppressions))
+	for _, s := range suppressions {
+		suppressed = append(suppressed, fmt.Sprintf("%s (%s)", s.Address, s.Reason))
+	}
+
+	err = fmt.Errorf("%w recipients: %s", task.ErrSuppressed, strings.Join(suppressed, ", "))
+	return &task.ExecutionError{Err: err, Permanent: true, Code: "suppressed"}
+}
+
+// sender returns the sender for the SMTP server of the account, or the worker's
+// one if the account doesn't have its own.
+func (e *SendEmailExecutor) sender(ctx context.Context, accountId string) (email.EmailSender, error) {
+	config, err := e.smtpConfigs.GetSMTPConfig(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			e.dropAccountSender(accountId)
+			return e.emailSender, nil
+		}
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cached, ok := e.accountSenders[accountId]
+	if ok && cached.updatedAt.Equal(config.UpdatedAt) {
+		return cached.sender, nil
+	}
+
+	password, err := e.smtpConfigs.GetSMTPPassword(config)
+	if err != nil {
+		return nil, task.Permanent(err)
+	}
+
+	emailSender, err := email.NewSMTPSender(&email.SMTPConfig{
+		Host:        config.Host,
+		Port:        config.Port,
+		Username:    config.Username,
+		Password:    password,
+		DialContext: e.guard.Dialer().DialContext,
+	})
+	if err != nil {
+		return nil, task.Permanent(fmt.Errorf("invalid smtp config: %w", err))
+	}
+
+	if ok {
+		// the config changed since the sender was created
+		go cached.sender.Close()
+		delete(e.accountSenders, accountId)
+	}
+	if len(e.accountSenders) >= maxAccountSenders {
+		for id, cached := range e.accountSenders {
+			go cached.sender.Close()
+			delete(e.accountSenders, id)
+		}
+	}
+	e.accountSenders[accountId] = accountSender{sender: emailSender, updatedAt: config.UpdatedAt}
+
+	return emailSender, nil
+}
+
+// dropAccountSender closes the sender of the account, e.g. after its SMTP config was deleted.
+func (e *SendEmailExecutor) dropAccountSender(accountId string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if cached, ok := e.accountSenders[accountId]; ok {
+		go cached.sender.Close()
+		delete(e.accountSenders, accountId)
+	}
+}
+
+// attachments loads the content of the payload attachments. Blobs are read
+// from the job's account, a blob deleted after the job was created fails it.
+func (e *SendEmailExecutor) attachments(ctx context.Context, accountId string, payloadAttachments []task.EmailAttachment) ([]email.Attachment, error) {
+	attachments := make([]email.Attachment, 0, len(payloadAttachments))
+
+	for _, a := range payloadAttachments {
+		attachment := email.Attachment{Filename: a.Filename, ContentType: a.ContentType}
+
+		if a.BlobID != "" {
+			b, content, err := e.blobService.ReadBlob(ctx, a.BlobID, accountId)
+			if err != nil {
+				if errors.Is(err, service.ErrRecordNotFound) {
+					return nil, task.Permanent(fmt.Errorf("attachment blob %s not found", a.BlobID))
+				}
+				return nil, fmt.Errorf("reading attachment blob %s: %w", a.BlobID, err)
+			}
+
+			attachment.Content = content
+			if attachment.Filename == "" {
+				attachment.Filename = b.Filename
+			}
+			if attachment.ContentType == "" {
+				attachment.ContentType = b.ContentType
+			}
+		} else {
+			content, err := base64.StdEncoding.DecodeString(a.Content)
+			if err != nil {
+				return nil, task.Permanent(fmt.Errorf("invalid attachment content: %w", err))
+			}
+			attachment.Content = content
+		}
+
+		if attachment.ContentType == "" {
+			attachment.ContentType = blob.DefaultContentType
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
 }
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// ranges not covered by the netip.Addr checks that must not be reachable either
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, including broadcast
+}
+
+// AddressGuard stops webhooks from reaching private, loopback and link-local
+// addresses, e.g. the cloud metadata endpoint or our own Postgres and Redis.
+// It's checked when connecting, after DNS resolution, so hostnames resolving
+// to internal addresses and redirects to them are blocked too.
+type AddressGuard struct {
+	// Networks that are reachable even if internal, set by the operator
+	allowed []netip.Prefix
+}
+
+func NewAddressGuard(allowed []netip.Prefix) *AddressGuard {
+	return &AddressGuard{allowed: allowed}
+}
+
+// Allowed reports whether webhooks can connect to the address.
+func (g *AddressGuard) Allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// control is used as the net.Dialer Control, it runs with the resolved address of each connection attempt.
+func (g *AddressGuard) control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !g.Allowed(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// Dialer returns a dialer that only connects to allowed addresses.
+func (g *AddressGuard) Dialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.control,
+	}
+}
@@ -0,0 +1,160 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/task"
+)
+
+const (
+	// tokens are refreshed this long before they expire, so they don't expire mid call
+	oauth2ExpiryMargin = 30 * time.Second
+	// how long tokens without expires_in are used for
+	oauth2DefaultTokenTTL = 5 * time.Minute
+	// upper bound for the size of a token response
+	oauth2MaxResponseSize = 1 << 20
+)
+
+type oauth2Token struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// oauth2TokenSource fetches OAuth2 client credentials tokens and caches them
+// until they expire, so calls to the same target share a token.
+type oauth2TokenSource struct {
+	client *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]oauth2Token
+}
+
+func newOAuth2TokenSource(client *http.Client) *oauth2TokenSource {
+	return &oauth2TokenSource{client: client, tokens: map[string]oauth2Token{}}
+}
+
+// cacheKey identifies the tokens of a client. The secret is part of it so a
+// rotated secret doesn't keep using the tokens got with the old one.
+func (s *oauth2TokenSource) cacheKey(auth *task.WebhookAuth, clientSecret string) string {
+	h := sha256.New()
+	for _, part := range []string{auth.TokenURL, auth.ClientID, clientSecret, strings.Join(auth.Scopes, " ")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Token returns a cached token for the client or fetches a new one.
+func (s *oauth2TokenSource) Token(ctx context.Context, auth *task.WebhookAuth, clientSecret string) (string, error) {
+	key := s.cacheKey(auth, clientSecret)
+	now := time.Now()
+
+	s.mu.Lock()
+	token, ok := s.tokens[key]
+	s.mu.Unlock()
+
+	if ok && now.Before(token.expiresAt) {
+		return token.accessToken, nil
+	}
+
+	token, err := s.fetch(ctx, auth, clientSecret)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, t := range s.tokens {
+		if !now.Before(t.expiresAt) {
+			delete(s.tokens, k)
+		}
+	}
+	s.tokens[key] = token
+
+	return token.accessToken, nil
+}
+
+// Invalidate drops the cached token of the client, e.g. after the target rejected it.
+func (s *oauth2TokenSource) Invalidate(auth *task.WebhookAuth, clientSecret string) {
+	key := s.cacheKey(auth, clientSecret)
+
+	s.mu.Lock()
+	delete(s.tokens, key)
+	s.mu.Unlock()
+}
+
+func (s *oauth2TokenSource) fetch(ctx context.Context, auth *task.WebhookAuth, clientSecret string) (oauth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, task.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// the credentials are form encoded before being used for basic auth, see RFC 6749 section 2.3.1
+	req.SetBasicAuth(url.QueryEscape(auth.ClientID), url.QueryEscape(clientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("fetching oauth2 token: %w", err)
+		if errors.Is(err, ErrForbiddenAddress) {
+			return oauth2Token{}, task.Permanent(err)
+		}
+		return oauth2Token{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oauth2MaxResponseSize))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("reading oauth2 token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return oauth2Token{}, &task.ExecutionError{
+			Err:        fmt.Errorf("oauth2 token request returned %s: %s", resp.Status, bytes.TrimSpace(body[:min(len(body), webhookErrorBodyLimit)])),
+			Permanent:  isPermanentStatus(resp.StatusCode),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	err = json.Unmarshal(body, &tokenResponse)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("invalid oauth2 token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return oauth2Token{}, errors.New("invalid oauth2 token response: missing access_token")
+	}
+	if tokenResponse.TokenType != "" && !strings.EqualFold(tokenResponse.TokenType, "bearer") {
+		return oauth2Token{}, task.Permanent(fmt.Errorf("unsupported oauth2 token type %q", tokenResponse.TokenType))
+	}
+
+	ttl := oauth2DefaultTokenTTL
+	if tokenResponse.ExpiresIn > 0 {
+		ttl = max(0, time.Duration(tokenResponse.ExpiresIn)*time.Second-oauth2ExpiryMargin)
+	}
+
+	return oauth2Token{accessToken: tokenResponse.AccessToken, expiresAt: time.Now().Add(ttl)}, nil
+}
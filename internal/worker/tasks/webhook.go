@@ -2,40 +2,229 @@ package tasks
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/service"
 	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/webhook"
 )
 
+const (
+	// how much of the response body goes into the error of a failed call
+	webhookErrorBodyLimit = 256
+	// upper bound for the Retry-After of a response, so a receiver can't hold a job for too long
+	webhookMaxRetryAfter = 24 * time.Hour
+)
+
+type WebhookConfig struct {
+	// Internal networks webhooks are allowed to call, e.g. for targets in the same VPC
+	AllowedNetworks []netip.Prefix
+}
+
 type WebhookExecutor struct {
-	logger *slog.Logger
+	logger         *slog.Logger
+	signingSecrets *service.SigningSecretService
+	secrets        *service.SecretService
+	clients        *webhookClients
+	tokens         *oauth2TokenSource
 }
 
-func NewWebhookExecutor(logger *slog.Logger) *WebhookExecutor {
-	return &WebhookExecutor{logger: logger}
+func NewWebhookExecutor(logger *slog.Logger, signingSecrets *service.SigningSecretService,
+	secrets *service.SecretService, config WebhookConfig) *WebhookExecutor {
+	clients := newWebhookClients(NewAddressGuard(config.AllowedNetworks))
+
+	return &WebhookExecutor{
+		logger:         logger,
+		signingSecrets: signingSecrets,
+		secrets:        secrets,
+		clients:        clients,
+		tokens:         newOAuth2TokenSource(clients.Default()),
+	}
 }
 
-// TODO
-func (e *WebhookExecutor) Execute(j job.Job) error {
+// Execute calls the webhook of the job. Responses with a status code outside the
+// payload success codes fail the job, permanently for 4xx ones other than 408 and 429.
+func (e *WebhookExecutor) Execute(ctx context.Context, j job.Job) error {
 	var payload task.WebhookPayload
 	if err := json.Unmarshal(j.Payload, &payload); err != nil {
-		return fmt.Errorf("invalid webhook payload: %w", err)
+		return task.Permanent(fmt.Errorf("invalid webhook payload: %w", err))
 	}
-	req, err := http.NewRequest(payload.Method, payload.URL, bytes.NewReader(payload.Body))
+
+	ctx, cancel := context.WithTimeout(ctx, payload.Timeout())
+	defer cancel()
+	ctx = contextSetRedirectLimit(ctx, payload.RedirectLimit())
+
+	req, err := newWebhookRequest(ctx, &payload)
+	if err != nil {
+		return task.Permanent(err)
+	}
+
+	var invalidateAuth func()
+	if payload.Auth != nil {
+		invalidateAuth, err = e.authorize(ctx, req, &j, payload.Auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	// set after the payload headers so they can't replace the signature
+	err = e.sign(ctx, req, &j, payload.Body)
 	if err != nil {
 		return err
 	}
-	client := &http.Client{}
+
+	client := e.clients.Default()
+	if payload.ClientCertRef != "" {
+		client, err = e.certificateClient(ctx, &j, payload.ClientCertRef)
+		if err != nil {
+			return err
+		}
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
+		if errors.Is(err, ErrForbiddenAddress) {
+			return task.Permanent(err)
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
-	fmt.Printf("Webhook call to %s returned %d\n", payload.URL, resp.StatusCode)
+	e.logger.Debug("webhook call returned", "jobId", j.ID, "url", payload.URL, "status", resp.StatusCode)
+
+	if payload.IsSuccess(resp.StatusCode) {
+		// draining the body lets the connection be reused
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	err = fmt.Errorf("webhook call returned %s", resp.Status)
+	if len(body) > 0 {
+		err = fmt.Errorf("%w: %s", err, bytes.TrimSpace(body))
+	}
+
+	permanent := isPermanentStatus(resp.StatusCode)
+
+	// a cached token can be revoked before it expires, the next attempt gets a new one
+	if resp.StatusCode == http.StatusUnauthorized && invalidateAuth != nil {
+		invalidateAuth()
+		permanent = false
+	}
+
+	return &task.ExecutionError{
+		Err:        err,
+		Permanent:  permanent,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// newWebhookRequest builds the request of the payload, without its auth and signature.
+func newWebhookRequest(ctx context.Context, payload *task.WebhookPayload) (*http.Request, error) {
+	u, err := url.Parse(payload.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload.Query) > 0 {
+		query := u.Query()
+		for name, value := range payload.Query {
+			query.Add(name, value)
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, payload.Method, u.String(), bytes.NewReader(payload.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range payload.Headers {
+		// the Host header is ignored by the client, the request field must be used instead
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	// the body is always JSON
+	if len(payload.Body) > 0 && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// certificateClient returns the client using the certificate of the job's account secret.
+func (e *WebhookExecutor) certificateClient(ctx context.Context, j *job.Job, certRef string) (*http.Client, error) {
+	certPEM, err := e.secretValue(ctx, j, certRef)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := e.clients.WithCertificate([]byte(certPEM))
+	if err != nil {
+		return nil, task.Permanent(fmt.Errorf("invalid client certificate in secret %q: %w", certRef, err))
+	}
+
+	return client, nil
+}
+
+// sign adds the signature headers to the request when the job's account has signing secrets.
+func (e *WebhookExecutor) sign(ctx context.Context, req *http.Request, j *job.Job, body []byte) error {
+	// jobs created before accounts owned them can't be signed
+	if j.AccountID == "" {
+		return nil
+	}
+
+	secrets, err := e.signingSecrets.GetActiveSecrets(ctx, j.AccountID)
+	if err != nil {
+		return fmt.Errorf("getting signing secrets: %w", err)
+	}
+
+	if len(secrets) > 0 {
+		webhook.SetHeaders(req.Header, secrets, j.ID, time.Now(), body)
+	}
+
 	return nil
 }
+
+// isPermanentStatus reports whether retrying a request that got the status code is pointless.
+func isPermanentStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return statusCode >= 400 && statusCode <= 499
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given either in
+// seconds or as an HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		// capped before converting so large values can't overflow
+		delay = time.Duration(min(seconds, int(webhookMaxRetryAfter/time.Second))) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = date.Sub(now)
+	}
+
+	return max(0, min(delay, webhookMaxRetryAfter))
+}
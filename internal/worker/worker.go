@@ -2,8 +2,12 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ngmmartins/asyncq/internal/email"
@@ -13,6 +17,7 @@ import (
 	"github.com/ngmmartins/asyncq/internal/store"
 	"github.com/ngmmartins/asyncq/internal/task"
 	"github.com/ngmmartins/asyncq/internal/worker/tasks"
+	"github.com/ngmmartins/asyncq/registry"
 )
 
 type Worker struct {
@@ -21,20 +26,37 @@ type Worker struct {
 	jobService    *service.JobService
 	taskExecutors map[task.Task]TaskExecutor
 	logger        *slog.Logger
+
+	// ids of the jobs being executed, whose heartbeat is kept by RunHeartbeats
+	runningMu sync.Mutex
+	running   map[string]struct{}
 }
 
 func New(store store.Store, queue queue.Queue, logger *slog.Logger,
-	jobService *service.JobService, emailSender email.EmailSender) *Worker {
+	jobService *service.JobService, secretService *service.SecretService, signingSecretService *service.SigningSecretService, blobService *service.BlobService,
+	smtpConfigService *service.SMTPConfigService, suppressionService *service.SuppressionService, emailSender email.EmailSender, webhookConfig tasks.WebhookConfig) *Worker {
+
+	// the SMTP servers of the accounts are guarded the same way as webhook targets
+	smtpGuard := tasks.NewAddressGuard(webhookConfig.AllowedNetworks)
+
+	taskExecutors := map[task.Task]TaskExecutor{
+		task.WebhookTask:   tasks.NewWebhookExecutor(logger, signingSecretService, secretService, webhookConfig),
+		task.SendEmailTask: tasks.NewSendEmailExecutor(logger, emailSender, blobService, smtpConfigService, suppressionService, smtpGuard),
+	}
+	// tasks registered by the binary embedding asyncq bring their own executor
+	for _, t := range registry.Tasks() {
+		if executor := t.Executor(); executor != nil {
+			taskExecutors[t.Name] = executor
+		}
+	}
 
 	return &Worker{
-		store:      store,
-		queue:      queue,
-		jobService: jobService,
-		taskExecutors: map[task.Task]TaskExecutor{
-			task.WebhookTask:   tasks.NewWebhookExecutor(logger),
-			task.SendEmailTask: tasks.NewSendEmailExecutor(logger, emailSender),
-		},
-		logger: logger,
+		store:         store,
+		queue:         queue,
+		jobService:    jobService,
+		taskExecutors: taskExecutors,
+		logger:        logger,
+		running:       map[string]struct{}{},
 	}
 }
 
@@ -67,105 +89,146 @@ func (w *Worker) Run(ctx context.Context, tickInterval time.Duration) {
 
 func (w *Worker) handleJob(ctx context.Context, jobId string) {
 	w.logger.Debug("handling job", "jobId", jobId)
-	// update job status and save it
-	err := w.jobService.UpdateJobStatus(ctx, jobId, job.StatusRunning)
+
+	j, err := w.jobService.GetJob(ctx, jobId)
 	if err != nil {
-		w.logger.Error("Error updating job status", "id", jobId, "newJobStatus", job.StatusRunning, "err", err.Error())
+		w.logger.Error("Error getting job from store", "id", jobId, "err", err.Error())
 		//TODO what to do here?
 		return
 	}
 
-	j, err := w.jobService.GetJob(ctx, jobId)
+	// external jobs are leased by their consumers, the worker only expires the ones left unleased
+	if j.Task == task.ExternalTask {
+		if j.Status == job.StatusQueued && j.ExpiresAt != nil && time.Now().After(*j.ExpiresAt) {
+			w.expireJob(ctx, j)
+		}
+		return
+	}
+
+	// a job dequeued late, e.g. because workers were down, must not run past its deadline
+	if j.ExpiresAt != nil && time.Now().After(*j.ExpiresAt) {
+		w.expireJob(ctx, j)
+		return
+	}
+
+	// update job status and save it
+	err = w.jobService.UpdateJobStatus(ctx, jobId, job.StatusRunning)
 	if err != nil {
-		w.logger.Error("Error getting job from store", "id", jobId, "err", err.Error())
+		w.logger.Error("Error updating job status", "id", jobId, "newJobStatus", job.StatusRunning, "err", err.Error())
 		//TODO what to do here?
 		return
 	}
 
-	err = w.executeTask(j)
-
-	now := time.Now()
-	updateFields := job.UpdateFields{}
+	// progress reported by a previous attempt doesn't apply anymore
+	if j.Progress != nil {
+		err = w.jobService.ClearJobProgress(ctx, jobId)
+		if err != nil {
+			w.logger.Error("Error clearing job progress", "id", jobId, "err", err.Error())
+		}
+	}
 
-	updateFields.SetFinishedAt = true
-	updateFields.FinishedAt = &now
+	w.startHeartbeat(ctx, jobId)
+	err = w.executeTask(ctx, j)
+	w.stopHeartbeat(jobId)
 
+	err = w.jobService.FinishJobAttempt(ctx, j, err)
 	if err != nil {
-		w.logger.Debug("job execution failed", "jobId", jobId, "err", err.Error())
-		updateFields.SetLastError = true
-		lastErr := err.Error()
-		updateFields.LastError = &lastErr
-
-		enqueueJob := false
-		// Check if the job still has retry attempts left
-		if j.Retries < j.MaxRetries {
-			w.logger.Debug("job still has remaining attempts", "jobId", jobId, "retries", j.Retries, "maxRetries", j.MaxRetries)
-			enqueueJob = true
-
-			updateFields.SetRetries = true
-			newRetries := j.Retries + 1
-			updateFields.Retries = &newRetries
-
-			updateFields.SetStatus = true
-			status := job.StatusQueued
-			updateFields.Status = &status
-
-			updateFields.SetRunAt = true
-			nextRunAt := now.Add(time.Second * time.Duration(j.RetryDelaySec))
-			updateFields.RunAt = &nextRunAt
-
-		} else {
-			w.logger.Debug("job does not have remaining attempts", "jobId", jobId, "retries", j.Retries, "maxRetries", j.MaxRetries)
-			updateFields.SetStatus = true
-			status := job.StatusFailed
-			updateFields.Status = &status
-		}
+		w.logger.Error("Error finishing job attempt", "id", jobId, "err", err.Error())
+		//TODO what to do here?
+	}
+}
 
-		w.logger.Debug("updating job fields", "jobId", jobId, "updateFields", updateFields)
-		err = w.jobService.UpdateJobFields(ctx, jobId, &updateFields)
-		if err != nil {
-			w.logger.Error("Error updating job fields", "id", jobId, "updateFields", updateFields, "err", err.Error())
-			//TODO what to do here?
+// expireJob finishes a job that is past its deadline without running it, unless
+// it left the Queued status meanwhile, e.g. because it was cancelled.
+func (w *Worker) expireJob(ctx context.Context, j *job.Job) {
+	w.logger.Debug("job expired before running", "jobId", j.ID, "expiresAt", j.ExpiresAt)
+
+	err := w.jobService.ExpireJob(ctx, j.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidStatusTransition) {
+			w.logger.Debug("job not expired, it's no longer queued", "jobId", j.ID, "err", err.Error())
 			return
 		}
+		w.logger.Error("Error expiring job", "id", j.ID, "err", err.Error())
+	}
+}
 
-		if enqueueJob {
-			w.logger.Debug("Enqueueing job again with new RunAt", "jobId", jobId, "RunAt", updateFields.RunAt)
-			// Enqueue the job again to be retried
-			err := w.queue.Enqueue(ctx, j.ID, *updateFields.RunAt)
+func (w *Worker) executeTask(ctx context.Context, j *job.Job) error {
+	executor, ok := w.taskExecutors[j.Task]
+	if !ok {
+		//TODO change to logger
+		return fmt.Errorf("unknown task: %s", j.Task)
+	}
+
+	ctx = job.ContextSetProgressReporter(ctx, func(ctx context.Context, percent int, message string) error {
+		return w.jobService.UpdateJobProgress(ctx, j.ID, percent, message)
+	})
+
+	return executor.Execute(ctx, *j)
+}
+
+// RunLeaseExpiry periodically fails the attempts of the external jobs whose lease
+// expired, e.g. because their consumer died, so they are retried like the jobs
+// run by the worker.
+func (w *Worker) RunLeaseExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	w.logger.Info(fmt.Sprintf("worker lease expiry configured with interval=%v", interval))
+
+	for {
+		select {
+		case <-ticker.C:
+			expired, err := w.jobService.ExpireLeases(ctx, time.Now())
 			if err != nil {
-				w.logger.Error("failed to enqueue job", "jobID", j.ID)
+				w.logger.Error("Error expiring job leases", "err", err.Error())
+			}
+			if expired > 0 {
+				w.logger.Info("expired job leases", "count", expired)
 			}
+		case <-ctx.Done():
+			return
 		}
-
-		return
 	}
+}
 
-	w.logger.Debug("job execution succedded", "jobId", jobId)
+// RunHeartbeats periodically updates the heartbeat of the jobs being executed, so
+// a job that is taking long can be told apart from one whose worker died.
+func (w *Worker) RunHeartbeats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	w.logger.Info(fmt.Sprintf("worker heartbeats configured with interval=%v", interval))
 
-	// Clear eventual past errors
-	updateFields.SetLastError = true
-	updateFields.LastError = nil
+	for {
+		select {
+		case <-ticker.C:
+			w.runningMu.Lock()
+			jobIds := slices.Collect(maps.Keys(w.running))
+			w.runningMu.Unlock()
+
+			err := w.jobService.HeartbeatJobs(ctx, jobIds)
+			if err != nil {
+				w.logger.Error("Error updating jobs heartbeat", "jobIds", jobIds, "err", err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-	updateFields.SetStatus = true
-	status := job.StatusDone
-	updateFields.Status = &status
+// startHeartbeat sends the first heartbeat of the job and registers it for the following ones.
+func (w *Worker) startHeartbeat(ctx context.Context, jobId string) {
+	w.runningMu.Lock()
+	w.running[jobId] = struct{}{}
+	w.runningMu.Unlock()
 
-	w.logger.Debug("updating job fields", "jobId", jobId, "updateFields", updateFields)
-	err = w.jobService.UpdateJobFields(ctx, jobId, &updateFields)
+	err := w.jobService.HeartbeatJobs(ctx, []string{jobId})
 	if err != nil {
-		w.logger.Error("Error updating job fields", "id", jobId, "updateFields", updateFields, "err", err.Error())
-		//TODO what to do here?
-		return
+		w.logger.Error("Error updating job heartbeat", "id", jobId, "err", err.Error())
 	}
-
 }
 
-func (w *Worker) executeTask(j *job.Job) error {
-	executor, ok := w.taskExecutors[j.Task]
-	if !ok {
-		//TODO change to logger
-		return fmt.Errorf("unknown task: %s", j.Task)
-	}
-	return executor.Execute(*j)
+func (w *Worker) stopHeartbeat(jobId string) {
+	w.runningMu.Lock()
+	delete(w.running, jobId)
+	w.runningMu.Unlock()
 }
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS jobs_account_id_idx;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS account_id;
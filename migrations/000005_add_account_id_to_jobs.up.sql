@@ -0,0 +1,3 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS account_id UUID REFERENCES accounts ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS jobs_account_id_idx ON jobs (account_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS job_events;
\ No newline at end of file
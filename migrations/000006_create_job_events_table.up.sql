@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS job_events (
+    id bigserial PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES jobs ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    task TEXT NOT NULL,
+    status TEXT NOT NULL,
+    previous_status TEXT,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS job_events_account_id_id_idx ON job_events (account_id, id);
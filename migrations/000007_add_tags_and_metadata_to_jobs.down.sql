@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS jobs_metadata_idx;
+DROP INDEX IF EXISTS jobs_tags_idx;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS metadata;
+ALTER TABLE jobs DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,5 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS metadata jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS jobs_tags_idx ON jobs USING GIN (tags);
+CREATE INDEX IF NOT EXISTS jobs_metadata_idx ON jobs USING GIN (metadata jsonb_path_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS jobs_last_error_trgm_idx;
+DROP INDEX IF EXISTS jobs_finished_at_idx;
+DROP INDEX IF EXISTS jobs_run_at_idx;
+DROP INDEX IF EXISTS jobs_created_at_idx;
+DROP INDEX IF EXISTS jobs_status_idx;
@@ -0,0 +1,7 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS jobs_status_idx ON jobs (status);
+CREATE INDEX IF NOT EXISTS jobs_created_at_idx ON jobs (created_at);
+CREATE INDEX IF NOT EXISTS jobs_run_at_idx ON jobs (run_at);
+CREATE INDEX IF NOT EXISTS jobs_finished_at_idx ON jobs (finished_at);
+CREATE INDEX IF NOT EXISTS jobs_last_error_trgm_idx ON jobs USING GIN (last_error gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS jobs_retention_idx;
+
+DROP TABLE IF EXISTS jobs_archive;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS job_retention_days;
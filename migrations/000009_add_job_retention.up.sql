@@ -0,0 +1,8 @@
+-- NULL uses the worker's default retention, 0 keeps the account's jobs forever
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS job_retention_days integer;
+
+CREATE TABLE IF NOT EXISTS jobs_archive (LIKE jobs INCLUDING DEFAULTS);
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS archived_at timestamp(0) with time zone NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS jobs_archive_account_id_idx ON jobs_archive (account_id);
+CREATE INDEX IF NOT EXISTS jobs_retention_idx ON jobs ((COALESCE(finished_at, created_at)));
@@ -0,0 +1,2 @@
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS expires_at;
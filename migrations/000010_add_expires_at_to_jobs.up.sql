@@ -0,0 +1,2 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS expires_at timestamp(0) with time zone;
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS expires_at timestamp(0) with time zone;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS jobs_heartbeat_at_idx;
+
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS heartbeat_at;
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS progress;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS heartbeat_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS progress;
@@ -0,0 +1,7 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS progress jsonb;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS heartbeat_at timestamp(0) with time zone;
+
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS progress jsonb;
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS heartbeat_at timestamp(0) with time zone;
+
+CREATE INDEX IF NOT EXISTS jobs_heartbeat_at_idx ON jobs (heartbeat_at) WHERE status = 'Running';
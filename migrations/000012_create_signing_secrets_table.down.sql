@@ -0,0 +1 @@
+DROP TABLE IF EXISTS signing_secrets;
\ No newline at end of file
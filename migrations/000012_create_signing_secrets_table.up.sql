@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS signing_secrets (
+    id UUID PRIMARY KEY,
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    secret text NOT NULL,
+    -- set when the secret is rotated, so receivers have time to switch to the new one
+    expires_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS signing_secrets_account_id_idx ON signing_secrets (account_id);
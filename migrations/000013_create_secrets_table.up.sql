@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS secrets (
+    id UUID PRIMARY KEY,
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    name text NOT NULL,
+    -- encrypted by the application, see secret.Cipher
+    value bytea NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL,
+    updated_at timestamp(0) with time zone NOT NULL,
+    UNIQUE (account_id, name)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS email_templates;
\ No newline at end of file
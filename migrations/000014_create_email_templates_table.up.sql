@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS email_templates (
+    id UUID PRIMARY KEY,
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    name text NOT NULL,
+    subject text NOT NULL,
+    text_body text NOT NULL DEFAULT '',
+    html_body text NOT NULL DEFAULT '',
+    created_at timestamp(0) with time zone NOT NULL,
+    updated_at timestamp(0) with time zone NOT NULL,
+    UNIQUE (account_id, name)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS blobs (
+    id UUID PRIMARY KEY,
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    filename text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS blobs_account_id_idx ON blobs (account_id);
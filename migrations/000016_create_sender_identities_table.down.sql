@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sender_identities;
\ No newline at end of file
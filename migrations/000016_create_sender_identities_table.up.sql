@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sender_identities (
+    id UUID PRIMARY KEY,
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    address text NOT NULL,
+    verification_token text NOT NULL,
+    verified_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL,
+    UNIQUE (account_id, address)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS smtp_configs;
\ No newline at end of file
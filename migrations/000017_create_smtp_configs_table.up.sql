@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS smtp_configs (
+    account_id uuid PRIMARY KEY REFERENCES accounts ON DELETE CASCADE,
+    host text NOT NULL,
+    port integer NOT NULL,
+    username text NOT NULL,
+    -- encrypted by the application, see secret.Cipher
+    password bytea NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL,
+    updated_at timestamp(0) with time zone NOT NULL
+);
@@ -0,0 +1,3 @@
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS last_error_code;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS last_error_code;
@@ -0,0 +1,3 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS last_error_code text;
+
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS last_error_code text;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    account_id uuid NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    address text NOT NULL,
+    reason text NOT NULL,
+    detail text NOT NULL DEFAULT '',
+    created_at timestamp(0) with time zone NOT NULL,
+    PRIMARY KEY (account_id, address)
+);
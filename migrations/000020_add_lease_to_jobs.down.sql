@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS jobs_lease_expires_at_idx;
+DROP INDEX IF EXISTS jobs_external_due_idx;
+
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS lease_id;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS lease_id;
\ No newline at end of file
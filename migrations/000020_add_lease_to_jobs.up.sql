@@ -0,0 +1,8 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS lease_id uuid;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS lease_expires_at timestamp(0) with time zone;
+
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS lease_id uuid;
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS lease_expires_at timestamp(0) with time zone;
+
+CREATE INDEX IF NOT EXISTS jobs_external_due_idx ON jobs (account_id, run_at) WHERE task = 'external' AND status = 'Queued';
+CREATE INDEX IF NOT EXISTS jobs_lease_expires_at_idx ON jobs (lease_expires_at) WHERE status = 'Running' AND lease_expires_at IS NOT NULL;
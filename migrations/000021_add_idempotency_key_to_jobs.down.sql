@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS jobs_account_idempotency_key_idx;
+ALTER TABLE jobs_archive DROP COLUMN IF EXISTS idempotency_key;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS idempotency_key;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS idempotency_key text;
+ALTER TABLE jobs_archive ADD COLUMN IF NOT EXISTS idempotency_key text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_account_idempotency_key_idx ON jobs (account_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
@@ -0,0 +1,4 @@
+DELETE FROM signing_secrets WHERE secret IS NULL;
+ALTER TABLE signing_secrets ALTER COLUMN secret SET NOT NULL;
+
+ALTER TABLE signing_secrets DROP COLUMN IF EXISTS value;
\ No newline at end of file
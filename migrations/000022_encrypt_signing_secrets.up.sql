@@ -0,0 +1,5 @@
+-- encrypted by the application, see secret.Cipher
+ALTER TABLE signing_secrets ADD COLUMN IF NOT EXISTS value bytea;
+
+-- only the secrets created before they were encrypted keep their plaintext, until they're rotated
+ALTER TABLE signing_secrets ALTER COLUMN secret DROP NOT NULL;
\ No newline at end of file
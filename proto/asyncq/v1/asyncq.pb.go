@@ -0,0 +1,1566 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/asyncq/v1/asyncq.proto
+
+// The gRPC API of asyncq, served by cmd/api alongside the REST API. Calls are
+// authenticated like the REST routes they mirror, with "Bearer <credentials>"
+// in the authorization metadata: an API key for JobService and the
+// authentication token of the account's user for APIKeyService.
+
+package asyncqv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Job struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Task      string                 `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	// The JSON payload the job was created with
+	Payload []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	RunAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	// e.g. Queued, see the job statuses of the REST API
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Retries        int32                  `protobuf:"varint,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	MaxRetries     int32                  `protobuf:"varint,10,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	RetryDelaySec  int32                  `protobuf:"varint,11,opt,name=retry_delay_sec,json=retryDelaySec,proto3" json:"retry_delay_sec,omitempty"`
+	LastError      *string                `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3,oneof" json:"last_error,omitempty"`
+	Tags           []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata       map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Progress       *Progress              `protobuf:"bytes,16,opt,name=progress,proto3" json:"progress,omitempty"`
+	HeartbeatAt    *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=heartbeat_at,json=heartbeatAt,proto3" json:"heartbeat_at,omitempty"`
+	LastErrorCode  *string                `protobuf:"bytes,18,opt,name=last_error_code,json=lastErrorCode,proto3,oneof" json:"last_error_code,omitempty"`
+	LeaseExpiresAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Job) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *Job) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Job) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Job) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *Job) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *Job) GetRetryDelaySec() int32 {
+	if x != nil {
+		return x.RetryDelaySec
+	}
+	return 0
+}
+
+func (x *Job) GetLastError() string {
+	if x != nil && x.LastError != nil {
+		return *x.LastError
+	}
+	return ""
+}
+
+func (x *Job) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Job) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Job) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Job) GetProgress() *Progress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Job) GetHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeartbeatAt
+	}
+	return nil
+}
+
+func (x *Job) GetLastErrorCode() string {
+	if x != nil && x.LastErrorCode != nil {
+		return *x.LastErrorCode
+	}
+	return ""
+}
+
+func (x *Job) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
+}
+
+type Progress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       int32                  `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Progress) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *Progress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Progress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  string                 `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// The JSON payload of the task
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	// If not set, run now
+	RunAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	MaxRetries    *int32                 `protobuf:"varint,4,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	RetryDelaySec *int32                 `protobuf:"varint,5,opt,name=retry_delay_sec,json=retryDelaySec,proto3,oneof" json:"retry_delay_sec,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobRequest) Reset() {
+	*x = CreateJobRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobRequest) ProtoMessage() {}
+
+func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateJobRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetRetryDelaySec() int32 {
+	if x != nil && x.RetryDelaySec != nil {
+		return *x.RetryDelaySec
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// The fields of SearchJobsRequest are the query parameters of GET /v1/jobs.
+type SearchJobsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Task           string                 `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	RunBefore      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=run_before,json=runBefore,proto3" json:"run_before,omitempty"`
+	RunAfter       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=run_after,json=runAfter,proto3" json:"run_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	FinishedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_before,json=finishedBefore,proto3" json:"finished_before,omitempty"`
+	FinishedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished_after,json=finishedAfter,proto3" json:"finished_after,omitempty"`
+	// Jobs must have one of the statuses
+	Statuses        []string               `protobuf:"bytes,8,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	RetriesGte      *int32                 `protobuf:"varint,9,opt,name=retries_gte,json=retriesGte,proto3,oneof" json:"retries_gte,omitempty"`
+	HasError        *bool                  `protobuf:"varint,10,opt,name=has_error,json=hasError,proto3,oneof" json:"has_error,omitempty"`
+	Q               string                 `protobuf:"bytes,11,opt,name=q,proto3" json:"q,omitempty"`
+	HeartbeatBefore *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=heartbeat_before,json=heartbeatBefore,proto3" json:"heartbeat_before,omitempty"`
+	// Jobs must have all the tags
+	Tags []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Jobs must have all the key/value pairs
+	Metadata map[string]string `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 1 if not set
+	Page int32 `protobuf:"varint,15,opt,name=page,proto3" json:"page,omitempty"`
+	// 20 if not set
+	PageSize int32 `protobuf:"varint,16,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// -created_at if not set
+	SortBy string `protobuf:"bytes,17,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// When set, keyset pagination is used instead of page. An empty cursor
+	// requests the first page, the next ones the next_cursor of the previous.
+	Cursor        *string `protobuf:"bytes,18,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchJobsRequest) Reset() {
+	*x = SearchJobsRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsRequest) ProtoMessage() {}
+
+func (x *SearchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsRequest.ProtoReflect.Descriptor instead.
+func (*SearchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchJobsRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetRunBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunBefore
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetRunAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAfter
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetFinishedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedBefore
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetFinishedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAfter
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetRetriesGte() int32 {
+	if x != nil && x.RetriesGte != nil {
+		return *x.RetriesGte
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetHasError() bool {
+	if x != nil && x.HasError != nil {
+		return *x.HasError
+	}
+	return false
+}
+
+func (x *SearchJobsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetHeartbeatBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeartbeatBefore
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type SearchJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Metadata      *PaginationMetadata    `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchJobsResponse) Reset() {
+	*x = SearchJobsResponse{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsResponse) ProtoMessage() {}
+
+func (x *SearchJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsResponse.ProtoReflect.Descriptor instead.
+func (*SearchJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetMetadata() *PaginationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type PaginationMetadata struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPage  int32                  `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	PageSize     int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	FirstPage    int32                  `protobuf:"varint,3,opt,name=first_page,json=firstPage,proto3" json:"first_page,omitempty"`
+	LastPage     int32                  `protobuf:"varint,4,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
+	TotalRecords int32                  `protobuf:"varint,5,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	// Only set when using keyset pagination and there are more records
+	NextCursor    string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationMetadata) Reset() {
+	*x = PaginationMetadata{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationMetadata) ProtoMessage() {}
+
+func (x *PaginationMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationMetadata.ProtoReflect.Descriptor instead.
+func (*PaginationMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PaginationMetadata) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetFirstPage() int32 {
+	if x != nil {
+		return x.FirstPage
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetTotalRecords() int32 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ScheduleJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleJobRequest) Reset() {
+	*x = ScheduleJobRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleJobRequest) ProtoMessage() {}
+
+func (x *ScheduleJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleJobRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScheduleJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleJobRequest) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Resumes a stream, sending first the events stored after this one
+	LastEventId   *int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchJobRequest) Reset() {
+	*x = WatchJobRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobRequest) ProtoMessage() {}
+
+func (x *WatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchJobRequest) GetLastEventId() int64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
+	}
+	return 0
+}
+
+type JobEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId  string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Task   string                 `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Status string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// Empty when the job was just created
+	PreviousStatus string                 `protobuf:"bytes,5,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JobEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobEvent) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobEvent) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *JobEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobEvent) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *JobEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type APIKey struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Only returned when the key is created
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If not set, the key doesn't expire
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{13}
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type GetAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAPIKeyRequest) Reset() {
+	*x = GetAPIKeyRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAPIKeyRequest) ProtoMessage() {}
+
+func (x *GetAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAPIKeyRequest) Reset() {
+	*x = DeleteAPIKeyRequest{}
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAPIKeyRequest) ProtoMessage() {}
+
+func (x *DeleteAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_asyncq_v1_asyncq_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_asyncq_v1_asyncq_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_proto_asyncq_v1_asyncq_proto protoreflect.FileDescriptor
+
+const file_proto_asyncq_v1_asyncq_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/asyncq/v1/asyncq.proto\x12\tasyncq.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x06\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04task\x18\x03 \x01(\tR\x04task\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x121\n" +
+	"\x06run_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x18\n" +
+	"\aretries\x18\t \x01(\x05R\aretries\x12\x1f\n" +
+	"\vmax_retries\x18\n" +
+	" \x01(\x05R\n" +
+	"maxRetries\x12&\n" +
+	"\x0fretry_delay_sec\x18\v \x01(\x05R\rretryDelaySec\x12\"\n" +
+	"\n" +
+	"last_error\x18\f \x01(\tH\x00R\tlastError\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x128\n" +
+	"\bmetadata\x18\x0e \x03(\v2\x1c.asyncq.v1.Job.MetadataEntryR\bmetadata\x129\n" +
+	"\n" +
+	"expires_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12/\n" +
+	"\bprogress\x18\x10 \x01(\v2\x13.asyncq.v1.ProgressR\bprogress\x12=\n" +
+	"\fheartbeat_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vheartbeatAt\x12+\n" +
+	"\x0flast_error_code\x18\x12 \x01(\tH\x01R\rlastErrorCode\x88\x01\x01\x12D\n" +
+	"\x10lease_expires_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_last_errorB\x12\n" +
+	"\x10_last_error_code\"y\n" +
+	"\bProgress\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x05R\apercent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbd\x03\n" +
+	"\x10CreateJobRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x121\n" +
+	"\x06run_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12$\n" +
+	"\vmax_retries\x18\x04 \x01(\x05H\x00R\n" +
+	"maxRetries\x88\x01\x01\x12+\n" +
+	"\x0fretry_delay_sec\x18\x05 \x01(\x05H\x01R\rretryDelaySec\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12E\n" +
+	"\bmetadata\x18\a \x03(\v2).asyncq.v1.CreateJobRequest.MetadataEntryR\bmetadata\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_max_retriesB\x12\n" +
+	"\x10_retry_delay_sec\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\a\n" +
+	"\x11SearchJobsRequest\x12\x12\n" +
+	"\x04task\x18\x01 \x01(\tR\x04task\x129\n" +
+	"\n" +
+	"run_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\trunBefore\x127\n" +
+	"\trun_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\brunAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12C\n" +
+	"\x0ffinished_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0efinishedBefore\x12A\n" +
+	"\x0efinished_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rfinishedAfter\x12\x1a\n" +
+	"\bstatuses\x18\b \x03(\tR\bstatuses\x12$\n" +
+	"\vretries_gte\x18\t \x01(\x05H\x00R\n" +
+	"retriesGte\x88\x01\x01\x12 \n" +
+	"\thas_error\x18\n" +
+	" \x01(\bH\x01R\bhasError\x88\x01\x01\x12\f\n" +
+	"\x01q\x18\v \x01(\tR\x01q\x12E\n" +
+	"\x10heartbeat_before\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0fheartbeatBefore\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12F\n" +
+	"\bmetadata\x18\x0e \x03(\v2*.asyncq.v1.SearchJobsRequest.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04page\x18\x0f \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x10 \x01(\x05R\bpageSize\x12\x17\n" +
+	"\asort_by\x18\x11 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\x06cursor\x18\x12 \x01(\tH\x02R\x06cursor\x88\x01\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_retries_gteB\f\n" +
+	"\n" +
+	"_has_errorB\t\n" +
+	"\a_cursor\"s\n" +
+	"\x12SearchJobsResponse\x12\"\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0e.asyncq.v1.JobR\x04jobs\x129\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1d.asyncq.v1.PaginationMetadataR\bmetadata\"\xd6\x01\n" +
+	"\x12PaginationMetadata\x12!\n" +
+	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"first_page\x18\x03 \x01(\x05R\tfirstPage\x12\x1b\n" +
+	"\tlast_page\x18\x04 \x01(\x05R\blastPage\x12#\n" +
+	"\rtotal_records\x18\x05 \x01(\x05R\ftotalRecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\"W\n" +
+	"\x12ScheduleJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x06run_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\\\n" +
+	"\x0fWatchJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03H\x00R\vlastEventId\x88\x01\x01B\x10\n" +
+	"\x0e_last_event_id\"\xc1\x01\n" +
+	"\bJobEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04task\x18\x03 \x01(\tR\x04task\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12'\n" +
+	"\x0fprevious_status\x18\x05 \x01(\tR\x0epreviousStatus\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd3\x01\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"d\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x14\n" +
+	"\x12ListAPIKeysRequest\"C\n" +
+	"\x13ListAPIKeysResponse\x12,\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x11.asyncq.v1.APIKeyR\aapiKeys\"\"\n" +
+	"\x10GetAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13DeleteAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x8c\x03\n" +
+	"\n" +
+	"JobService\x128\n" +
+	"\tCreateJob\x12\x1b.asyncq.v1.CreateJobRequest\x1a\x0e.asyncq.v1.Job\x122\n" +
+	"\x06GetJob\x12\x18.asyncq.v1.GetJobRequest\x1a\x0e.asyncq.v1.Job\x12I\n" +
+	"\n" +
+	"SearchJobs\x12\x1c.asyncq.v1.SearchJobsRequest\x1a\x1d.asyncq.v1.SearchJobsResponse\x12D\n" +
+	"\vScheduleJob\x12\x1d.asyncq.v1.ScheduleJobRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tCancelJob\x12\x1b.asyncq.v1.CancelJobRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\bWatchJob\x12\x1a.asyncq.v1.WatchJobRequest\x1a\x13.asyncq.v1.JobEvent0\x012\xa5\x02\n" +
+	"\rAPIKeyService\x12A\n" +
+	"\fCreateAPIKey\x12\x1e.asyncq.v1.CreateAPIKeyRequest\x1a\x11.asyncq.v1.APIKey\x12L\n" +
+	"\vListAPIKeys\x12\x1d.asyncq.v1.ListAPIKeysRequest\x1a\x1e.asyncq.v1.ListAPIKeysResponse\x12;\n" +
+	"\tGetAPIKey\x12\x1b.asyncq.v1.GetAPIKeyRequest\x1a\x11.asyncq.v1.APIKey\x12F\n" +
+	"\fDeleteAPIKey\x12\x1e.asyncq.v1.DeleteAPIKeyRequest\x1a\x16.google.protobuf.EmptyB7Z5github.com/ngmmartins/asyncq/proto/asyncq/v1;asyncqv1b\x06proto3"
+
+var (
+	file_proto_asyncq_v1_asyncq_proto_rawDescOnce sync.Once
+	file_proto_asyncq_v1_asyncq_proto_rawDescData []byte
+)
+
+func file_proto_asyncq_v1_asyncq_proto_rawDescGZIP() []byte {
+	file_proto_asyncq_v1_asyncq_proto_rawDescOnce.Do(func() {
+		file_proto_asyncq_v1_asyncq_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_asyncq_v1_asyncq_proto_rawDesc), len(file_proto_asyncq_v1_asyncq_proto_rawDesc)))
+	})
+	return file_proto_asyncq_v1_asyncq_proto_rawDescData
+}
+
+var file_proto_asyncq_v1_asyncq_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_asyncq_v1_asyncq_proto_goTypes = []any{
+	(*Job)(nil),                   // 0: asyncq.v1.Job
+	(*Progress)(nil),              // 1: asyncq.v1.Progress
+	(*CreateJobRequest)(nil),      // 2: asyncq.v1.CreateJobRequest
+	(*GetJobRequest)(nil),         // 3: asyncq.v1.GetJobRequest
+	(*SearchJobsRequest)(nil),     // 4: asyncq.v1.SearchJobsRequest
+	(*SearchJobsResponse)(nil),    // 5: asyncq.v1.SearchJobsResponse
+	(*PaginationMetadata)(nil),    // 6: asyncq.v1.PaginationMetadata
+	(*ScheduleJobRequest)(nil),    // 7: asyncq.v1.ScheduleJobRequest
+	(*CancelJobRequest)(nil),      // 8: asyncq.v1.CancelJobRequest
+	(*WatchJobRequest)(nil),       // 9: asyncq.v1.WatchJobRequest
+	(*JobEvent)(nil),              // 10: asyncq.v1.JobEvent
+	(*APIKey)(nil),                // 11: asyncq.v1.APIKey
+	(*CreateAPIKeyRequest)(nil),   // 12: asyncq.v1.CreateAPIKeyRequest
+	(*ListAPIKeysRequest)(nil),    // 13: asyncq.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),   // 14: asyncq.v1.ListAPIKeysResponse
+	(*GetAPIKeyRequest)(nil),      // 15: asyncq.v1.GetAPIKeyRequest
+	(*DeleteAPIKeyRequest)(nil),   // 16: asyncq.v1.DeleteAPIKeyRequest
+	nil,                           // 17: asyncq.v1.Job.MetadataEntry
+	nil,                           // 18: asyncq.v1.CreateJobRequest.MetadataEntry
+	nil,                           // 19: asyncq.v1.SearchJobsRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 21: google.protobuf.Empty
+}
+var file_proto_asyncq_v1_asyncq_proto_depIdxs = []int32{
+	20, // 0: asyncq.v1.Job.run_at:type_name -> google.protobuf.Timestamp
+	20, // 1: asyncq.v1.Job.created_at:type_name -> google.protobuf.Timestamp
+	20, // 2: asyncq.v1.Job.finished_at:type_name -> google.protobuf.Timestamp
+	17, // 3: asyncq.v1.Job.metadata:type_name -> asyncq.v1.Job.MetadataEntry
+	20, // 4: asyncq.v1.Job.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: asyncq.v1.Job.progress:type_name -> asyncq.v1.Progress
+	20, // 6: asyncq.v1.Job.heartbeat_at:type_name -> google.protobuf.Timestamp
+	20, // 7: asyncq.v1.Job.lease_expires_at:type_name -> google.protobuf.Timestamp
+	20, // 8: asyncq.v1.Progress.updated_at:type_name -> google.protobuf.Timestamp
+	20, // 9: asyncq.v1.CreateJobRequest.run_at:type_name -> google.protobuf.Timestamp
+	18, // 10: asyncq.v1.CreateJobRequest.metadata:type_name -> asyncq.v1.CreateJobRequest.MetadataEntry
+	20, // 11: asyncq.v1.CreateJobRequest.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 12: asyncq.v1.SearchJobsRequest.run_before:type_name -> google.protobuf.Timestamp
+	20, // 13: asyncq.v1.SearchJobsRequest.run_after:type_name -> google.protobuf.Timestamp
+	20, // 14: asyncq.v1.SearchJobsRequest.created_before:type_name -> google.protobuf.Timestamp
+	20, // 15: asyncq.v1.SearchJobsRequest.created_after:type_name -> google.protobuf.Timestamp
+	20, // 16: asyncq.v1.SearchJobsRequest.finished_before:type_name -> google.protobuf.Timestamp
+	20, // 17: asyncq.v1.SearchJobsRequest.finished_after:type_name -> google.protobuf.Timestamp
+	20, // 18: asyncq.v1.SearchJobsRequest.heartbeat_before:type_name -> google.protobuf.Timestamp
+	19, // 19: asyncq.v1.SearchJobsRequest.metadata:type_name -> asyncq.v1.SearchJobsRequest.MetadataEntry
+	0,  // 20: asyncq.v1.SearchJobsResponse.jobs:type_name -> asyncq.v1.Job
+	6,  // 21: asyncq.v1.SearchJobsResponse.metadata:type_name -> asyncq.v1.PaginationMetadata
+	20, // 22: asyncq.v1.ScheduleJobRequest.run_at:type_name -> google.protobuf.Timestamp
+	20, // 23: asyncq.v1.JobEvent.created_at:type_name -> google.protobuf.Timestamp
+	20, // 24: asyncq.v1.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 25: asyncq.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	20, // 26: asyncq.v1.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 27: asyncq.v1.ListAPIKeysResponse.api_keys:type_name -> asyncq.v1.APIKey
+	2,  // 28: asyncq.v1.JobService.CreateJob:input_type -> asyncq.v1.CreateJobRequest
+	3,  // 29: asyncq.v1.JobService.GetJob:input_type -> asyncq.v1.GetJobRequest
+	4,  // 30: asyncq.v1.JobService.SearchJobs:input_type -> asyncq.v1.SearchJobsRequest
+	7,  // 31: asyncq.v1.JobService.ScheduleJob:input_type -> asyncq.v1.ScheduleJobRequest
+	8,  // 32: asyncq.v1.JobService.CancelJob:input_type -> asyncq.v1.CancelJobRequest
+	9,  // 33: asyncq.v1.JobService.WatchJob:input_type -> asyncq.v1.WatchJobRequest
+	12, // 34: asyncq.v1.APIKeyService.CreateAPIKey:input_type -> asyncq.v1.CreateAPIKeyRequest
+	13, // 35: asyncq.v1.APIKeyService.ListAPIKeys:input_type -> asyncq.v1.ListAPIKeysRequest
+	15, // 36: asyncq.v1.APIKeyService.GetAPIKey:input_type -> asyncq.v1.GetAPIKeyRequest
+	16, // 37: asyncq.v1.APIKeyService.DeleteAPIKey:input_type -> asyncq.v1.DeleteAPIKeyRequest
+	0,  // 38: asyncq.v1.JobService.CreateJob:output_type -> asyncq.v1.Job
+	0,  // 39: asyncq.v1.JobService.GetJob:output_type -> asyncq.v1.Job
+	5,  // 40: asyncq.v1.JobService.SearchJobs:output_type -> asyncq.v1.SearchJobsResponse
+	21, // 41: asyncq.v1.JobService.ScheduleJob:output_type -> google.protobuf.Empty
+	21, // 42: asyncq.v1.JobService.CancelJob:output_type -> google.protobuf.Empty
+	10, // 43: asyncq.v1.JobService.WatchJob:output_type -> asyncq.v1.JobEvent
+	11, // 44: asyncq.v1.APIKeyService.CreateAPIKey:output_type -> asyncq.v1.APIKey
+	14, // 45: asyncq.v1.APIKeyService.ListAPIKeys:output_type -> asyncq.v1.ListAPIKeysResponse
+	11, // 46: asyncq.v1.APIKeyService.GetAPIKey:output_type -> asyncq.v1.APIKey
+	21, // 47: asyncq.v1.APIKeyService.DeleteAPIKey:output_type -> google.protobuf.Empty
+	38, // [38:48] is the sub-list for method output_type
+	28, // [28:38] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_proto_asyncq_v1_asyncq_proto_init() }
+func file_proto_asyncq_v1_asyncq_proto_init() {
+	if File_proto_asyncq_v1_asyncq_proto != nil {
+		return
+	}
+	file_proto_asyncq_v1_asyncq_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_asyncq_v1_asyncq_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_asyncq_v1_asyncq_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_asyncq_v1_asyncq_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_asyncq_v1_asyncq_proto_rawDesc), len(file_proto_asyncq_v1_asyncq_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_proto_asyncq_v1_asyncq_proto_goTypes,
+		DependencyIndexes: file_proto_asyncq_v1_asyncq_proto_depIdxs,
+		MessageInfos:      file_proto_asyncq_v1_asyncq_proto_msgTypes,
+	}.Build()
+	File_proto_asyncq_v1_asyncq_proto = out.File
+	file_proto_asyncq_v1_asyncq_proto_goTypes = nil
+	file_proto_asyncq_v1_asyncq_proto_depIdxs = nil
+}
@@ -0,0 +1,23 @@
+package registry
+
+import "github.com/ngmmartins/asyncq/internal/task"
+
+func init() {
+	register(Definition[task.WebhookPayload]{
+		Name:        string(task.WebhookTask),
+		Description: "Calls an HTTP endpoint",
+		Validate:    task.ValidateWebhookPayload,
+	})
+
+	register(Definition[task.SendEmailPayload]{
+		Name:        string(task.SendEmailTask),
+		Description: "Sends an email, from a verified sender identity of the account",
+		Validate:    task.ValidateSendEmailPayload,
+	})
+
+	register(Definition[task.ExternalPayload]{
+		Name:        string(task.ExternalTask),
+		Description: "Leased and run by an external consumer of the jobs of its name",
+		Validate:    task.ValidateExternalPayload,
+	})
+}
@@ -0,0 +1,225 @@
+// Package registry keeps the tasks jobs can be created with: how their payloads
+// are decoded and validated, and the executor that runs them.
+//
+// The webhook and send_email tasks are built in. Other tasks are added with
+// [Register], which must be called with the same definitions by both the API,
+// which validates the payloads of the jobs created, and the worker, which runs
+// them, before they start. A module embedding asyncq builds its own binaries
+// with [github.com/ngmmartins/asyncq/server.Run] and
+// [github.com/ngmmartins/asyncq/worker.Run], importing a package of its own
+// that registers the tasks from its init function:
+//
+//	// cmd/worker/main.go of the module
+//	import (
+//		_ "example.com/app/tasks"
+//		"github.com/ngmmartins/asyncq/worker"
+//	)
+//
+//	func main() {
+//		worker.Run()
+//	}
+//
+// and in package tasks:
+//
+//	type ResizePayload struct {
+//		BlobID string `json:"blob_id"`
+//		Width  int    `json:"width"`
+//	}
+//
+//	func init() {
+//		registry.Register(registry.Definition[ResizePayload]{
+//			Name:        "resize_image",
+//			Description: "Resizes an uploaded image",
+//			Validate: func(v *registry.Validator, p *ResizePayload) {
+//				v.CheckRequired(p.BlobID != "", "payload.blob_id")
+//				v.Check(p.Width > 0, "payload.width", "must be greater than 0")
+//			},
+//			Executor: registry.ExecutorFunc(resize),
+//		})
+//	}
+//
+//	func resize(ctx context.Context, j registry.Job) error {
+//		var p ResizePayload
+//		if err := json.Unmarshal(j.Payload, &p); err != nil {
+//			return registry.Permanent(err)
+//		}
+//		...
+//	}
+//
+// The payloads are decoded with unknown fields disallowed, and their JSON Schema,
+// listed by GET /v1/tasks, is derived from the payload type.
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+type (
+	// Job is the job an executor runs, its payload is the JSON the job was created with.
+	Job = job.Job
+	// Validator collects the validation errors of a payload, which are returned by
+	// the API with the job creation request.
+	Validator = validator.Validator
+	// ExecutionError tells the worker how a failed job is retried, see [Permanent].
+	ExecutionError = task.ExecutionError
+)
+
+// Permanent marks err as a failure that retrying won't fix, failing the job
+// without using its remaining retries.
+func Permanent(err error) error {
+	return task.Permanent(err)
+}
+
+// ReportProgress reports the progress of the job being executed, see [job.ReportProgress].
+func ReportProgress(ctx context.Context, percent int, message string) error {
+	return job.ReportProgress(ctx, percent, message)
+}
+
+// Executor runs the task of a job. Returning an error fails the attempt, which is
+// retried while the job has retries left.
+type Executor interface {
+	Execute(ctx context.Context, j Job) error
+}
+
+// ExecutorFunc adapts a function to an [Executor].
+type ExecutorFunc func(ctx context.Context, j Job) error
+
+func (f ExecutorFunc) Execute(ctx context.Context, j Job) error {
+	return f(ctx, j)
+}
+
+// Definition describes a task whose payloads are of type P.
+type Definition[P any] struct {
+	// The task of the jobs, e.g. resize_image
+	Name        string
+	Description string
+	// Checks the decoded payload of the jobs created, nil if any payload is valid.
+	// Errors are keyed by the JSON path of the field, e.g. payload.width
+	Validate func(v *Validator, payload *P)
+	Executor Executor
+}
+
+// Task is a registered task.
+type Task struct {
+	Name          task.Task      `json:"name"`
+	Description   string         `json:"description"`
+	PayloadSchema map[string]any `json:"payload_schema"`
+
+	decode   func(data json.RawMessage, v *validator.Validator) (any, error)
+	executor Executor
+}
+
+// Executor returns the executor of the task, which is nil for the built in tasks
+// as the worker creates theirs.
+func (t *Task) Executor() Executor {
+	return t.executor
+}
+
+var (
+	tasksMu sync.RWMutex
+	tasks   = map[task.Task]*Task{}
+
+	taskNameRX = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+// Register makes a task available to be created and run. It panics if the name
+// is taken or invalid, or the definition has no executor.
+func Register[P any](def Definition[P]) {
+	if def.Executor == nil {
+		panic(fmt.Sprintf("registry: task %q has no executor", def.Name))
+	}
+	register(def)
+}
+
+// register registers the task, allowing built in ones without an executor.
+func register[P any](def Definition[P]) {
+	if !taskNameRX.MatchString(def.Name) {
+		panic(fmt.Sprintf("registry: invalid task name %q, it must be up to 64 lowercase letters, digits and underscores", def.Name))
+	}
+
+	t := &Task{
+		Name:          task.Task(def.Name),
+		Description:   def.Description,
+		PayloadSchema: schemaOf(reflect.TypeFor[P]()),
+		executor:      def.Executor,
+		decode: func(data json.RawMessage, v *validator.Validator) (any, error) {
+			var p P
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.DisallowUnknownFields()
+
+			if err := dec.Decode(&p); err != nil {
+				return nil, err
+			}
+
+			if v != nil && def.Validate != nil {
+				def.Validate(v, &p)
+			}
+			return p, nil
+		},
+	}
+
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+
+	if _, ok := tasks[t.Name]; ok {
+		panic(fmt.Sprintf("registry: task %q registered twice", def.Name))
+	}
+	tasks[t.Name] = t
+}
+
+// Get returns the registered task, or false if there's none with the name.
+func Get(name task.Task) (*Task, bool) {
+	tasksMu.RLock()
+	defer tasksMu.RUnlock()
+
+	t, ok := tasks[name]
+	return t, ok
+}
+
+// Exists reports whether a task is registered with the name.
+func Exists(name task.Task) bool {
+	_, ok := Get(name)
+	return ok
+}
+
+// Tasks returns the registered tasks, sorted by name.
+func Tasks() []*Task {
+	tasksMu.RLock()
+	defer tasksMu.RUnlock()
+
+	list := make([]*Task, 0, len(tasks))
+	for _, t := range tasks {
+		list = append(list, t)
+	}
+	slices.SortFunc(list, func(a, b *Task) int { return strings.Compare(string(a.Name), string(b.Name)) })
+
+	return list
+}
+
+// DecodePayload decodes the payload of a job of the task into its payload type.
+func DecodePayload(name task.Task, data json.RawMessage) (any, error) {
+	return DecodeAndValidatePayload(name, data, nil)
+}
+
+// DecodeAndValidatePayload decodes the payload like [DecodePayload], adding its
+// validation errors to v.
+func DecodeAndValidatePayload(name task.Task, data json.RawMessage, v *validator.Validator) (any, error) {
+	t, ok := Get(name)
+	if !ok {
+		return nil, errors.New("no task registered with name: " + string(name))
+	}
+	return t.decode(data, v)
+}
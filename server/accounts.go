@@ -0,0 +1,53 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ngmmartins/asyncq/internal/account"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+func (app *application) getJobRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.writeJSON(w, http.StatusOK, envelope{"job_retention_days": acc.JobRetentionDays}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// updateJobRetentionHandler overrides the worker's default retention for the account's jobs.
+// A null value goes back to the default one.
+func (app *application) updateJobRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	var input account.UpdateJobRetentionRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	err = app.accountService.UpdateJobRetention(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		case errors.Is(err, service.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"job_retention_days": input.JobRetentionDays}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
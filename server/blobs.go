@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// uploadBlobHandler stores the raw request body as a blob. The filename is
+// given in the query string and the content type is taken from the request.
+func (app *application) uploadBlobHandler(w http.ResponseWriter, r *http.Request) {
+	filename := r.URL.Query().Get("filename")
+	contentType := r.Header.Get("Content-Type")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	b, err := app.blobService.UploadBlob(r.Context(), acc.ID, filename, contentType, r.Body)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		case errors.Is(err, service.ErrBlobTooLarge):
+			app.contentTooLargeResponse(w, r, err)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"blob": b}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getBlobHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	b, err := app.blobService.GetBlob(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"blob": b}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteBlobHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	// we use the accountId to ensure that the user doesn't delete a blob from other account
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.blobService.DeleteBlob(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/ngmmartins/asyncq/internal/emailtemplate"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+func (app *application) createEmailTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	var input emailtemplate.Request
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	template, err := app.emailTemplateService.CreateEmailTemplate(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"emailTemplate": template}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getEmailTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	templates, err := app.emailTemplateService.GetEmailTemplates(r.Context(), acc.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"emailTemplates": templates}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getEmailTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	// we use the accountId to ensure that the user doesn't get a template from other account
+	acc := util.ContextGetAccount(r.Context())
+
+	template, err := app.emailTemplateService.GetEmailTemplate(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"emailTemplate": template}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) updateEmailTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	var input emailtemplate.Request
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	template, err := app.emailTemplateService.UpdateEmailTemplate(r.Context(), id, acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		case errors.Is(err, service.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"emailTemplate": template}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteEmailTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	// we use the accountId to ensure that the user doesn't delete a template from other account
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.emailTemplateService.DeleteEmailTemplate(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package server
 
 import (
 	"fmt"
@@ -86,3 +86,7 @@ func (app *application) invalidAPIKeyResponse(w http.ResponseWriter, r *http.Req
 	message := "invalid or missing API Key"
 	app.errorResponse(w, r, http.StatusUnauthorized, message)
 }
+
+func (app *application) contentTooLargeResponse(w http.ResponseWriter, r *http.Request, err error) {
+	app.errorResponse(w, r, http.StatusRequestEntityTooLarge, err.Error())
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+const (
+	// how many stored events are read at a time when a client resumes a stream
+	eventsReplayBatchSize = 500
+	// comment lines are sent periodically so proxies don't close idle streams
+	eventsKeepAliveInterval = 15 * time.Second
+)
+
+// jobEventsHandler streams the status changes of the account's jobs as Server-Sent Events.
+// Clients resume a stream by sending the Last-Event-ID header (or the last_event_id
+// query parameter), in which case the events stored after that one are sent first.
+func (app *application) jobEventsHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	filter := app.readEventFilter(r, v)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+	filter.AccountID = acc.ID
+
+	// Subscribe before replaying so nothing published in between is lost.
+	// Live events up to the last replayed one were already sent by the replay
+	// and are skipped by their ID.
+	sub, err := app.jobService.SubscribeJobEvents(r.Context(), filter)
+	if err != nil {
+		var validationError *validator.ValidationError
+		if errors.As(err, &validationError) {
+			app.failedValidationResponse(w, r, validationError.Errors)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+
+	// The server WriteTimeout applies to the whole response, which would cut
+	// the stream after a few seconds, so it's lifted for this response only.
+	err = rc.SetWriteDeadline(time.Time{})
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	err = rc.Flush()
+	if err != nil {
+		app.logError(r, err)
+		return
+	}
+
+	if filter.AfterID > 0 {
+		for {
+			events, err := app.jobService.ListJobEvents(r.Context(), filter, eventsReplayBatchSize)
+			if err != nil {
+				app.logError(r, err)
+				return
+			}
+
+			for _, e := range events {
+				err = app.writeEvent(w, rc, e)
+				if err != nil {
+					app.logError(r, err)
+					return
+				}
+				filter.AfterID = e.ID
+			}
+
+			if len(events) < eventsReplayBatchSize {
+				break
+			}
+		}
+	}
+
+	ticker := time.NewTicker(eventsKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if !filter.Matches(e) {
+				continue
+			}
+
+			// AfterID isn't advanced here: events are published by many processes,
+			// so a lower ID can arrive after a higher one and must still be sent.
+			err = app.writeEvent(w, rc, e)
+			if err != nil {
+				app.logError(r, err)
+				return
+			}
+
+		case <-ticker.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			if err == nil {
+				err = rc.Flush()
+			}
+			if err != nil {
+				app.logError(r, err)
+				return
+			}
+
+		case <-app.shutdown:
+			return
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (app *application) writeEvent(w http.ResponseWriter, rc *http.ResponseController, e *event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", e.ID, data)
+	if err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+func (app *application) readEventFilter(r *http.Request, v *validator.Validator) *event.Filter {
+	filter := &event.Filter{}
+
+	queryString := r.URL.Query()
+
+	t := app.readString(queryString, "task", "")
+	if t != "" {
+		filter.Task = task.Task(t)
+	}
+
+	status := app.readString(queryString, "status", "")
+	if status != "" {
+		filter.Status = job.Status(status)
+	}
+
+	filter.JobID = app.readString(queryString, "job_id", "")
+
+	// EventSource sends the header on reconnection; the query parameter is
+	// there for clients resuming a stream on a new EventSource.
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = queryString.Get("last_event_id")
+	}
+
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			v.AddError("last_event_id", "must be an integer value")
+		}
+		filter.AfterID = id
+	}
+
+	return filter
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+const (
+	// how many jobs are written between each flush of the response
+	exportFlushInterval = 500
+	// how long the client has to read each flushed batch of an export
+	exportWriteTimeout = 30 * time.Second
+)
+
+var exportContentTypes = map[string]string{
+	"ndjson": "application/x-ndjson",
+	"csv":    "text/csv",
+}
+
+var jobCSVHeader = []string{"id", "account_id", "task", "status", "run_at", "created_at", "finished_at",
+	"retries", "max_retries", "retry_delay_sec", "last_error", "last_error_code", "tags", "metadata", "payload"}
+
+// exportJobsHandler streams every job matching the search filters as NDJSON or CSV.
+// Pagination parameters are ignored, the response holds all the matching jobs.
+func (app *application) exportJobsHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	criteria := app.readSearchCriteria(r, v)
+
+	format := app.readString(r.URL.Query(), "format", "ndjson")
+	contentType, ok := exportContentTypes[format]
+	v.Check(ok, "format", "must be ndjson or csv")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	csvWriter := csv.NewWriter(w)
+	jsonEncoder := json.NewEncoder(w)
+
+	// The response only starts with the first job so errors happening before
+	// it, like validation ones, still get a proper error response.
+	started := false
+	start := func() error {
+		started = true
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="jobs.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+
+		if format == "csv" {
+			return csvWriter.Write(jobCSVHeader)
+		}
+		return nil
+	}
+
+	write := func(j *job.Job) error {
+		if format == "csv" {
+			return csvWriter.Write(jobCSVRecord(j))
+		}
+		return jsonEncoder.Encode(j)
+	}
+
+	flush := func() error {
+		csvWriter.Flush()
+		err := csvWriter.Error()
+		if err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	written := 0
+
+	err := app.jobService.ExportJobs(r.Context(), criteria, func(j *job.Job) error {
+		if !started {
+			err := start()
+			if err != nil {
+				return err
+			}
+		}
+
+		err := write(j)
+		if err != nil {
+			return err
+		}
+		written++
+
+		if written%exportFlushInterval != 0 {
+			return nil
+		}
+
+		err = flush()
+		if err != nil {
+			return err
+		}
+
+		// The server WriteTimeout applies to the whole response, so it's
+		// extended for as long as the client keeps reading the export.
+		return rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	})
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		case !started:
+			app.serverErrorResponse(w, r, err)
+		default:
+			// the status was already sent, the client gets a truncated export
+			app.logError(r, err)
+		}
+		return
+	}
+
+	if !started {
+		err = start()
+	}
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		app.logError(r, err)
+	}
+}
+
+func jobCSVRecord(j *job.Job) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	lastError := ""
+	if j.LastError != nil {
+		lastError = *j.LastError
+	}
+	lastErrorCode := ""
+	if j.LastErrorCode != nil {
+		lastErrorCode = *j.LastErrorCode
+	}
+
+	// a map can always be marshalled
+	metadata, _ := json.Marshal(j.Metadata)
+
+	return []string{
+		j.ID,
+		j.AccountID,
+		string(j.Task),
+		string(j.Status),
+		formatTime(j.RunAt),
+		formatTime(&j.CreatedAt),
+		formatTime(j.FinishedAt),
+		strconv.Itoa(j.Retries),
+		strconv.Itoa(j.MaxRetries),
+		strconv.Itoa(j.RetryDelaySec),
+		lastError,
+		lastErrorCode,
+		strings.Join(j.Tags, ","),
+		string(metadata),
+		string(j.Payload),
+	}
+}
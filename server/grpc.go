@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/account"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/token"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+	asyncqv1 "github.com/ngmmartins/asyncq/proto/asyncq/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcServer serves the gRPC API, see proto/asyncq/v1/asyncq.proto. Its methods
+// share the services of the REST handlers and are authenticated the same way:
+// the jobs with an API key and the API keys with the authentication token of
+// the account's user, of an activated account.
+type grpcServer struct {
+	asyncqv1.UnimplementedJobServiceServer
+	asyncqv1.UnimplementedAPIKeyServiceServer
+	app *application
+}
+
+func (app *application) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(app.recoverPanicUnary, app.authenticateUnary),
+		grpc.ChainStreamInterceptor(app.recoverPanicStream, app.authenticateStream),
+	)
+
+	s := &grpcServer{app: app}
+	asyncqv1.RegisterJobServiceServer(srv, s)
+	asyncqv1.RegisterAPIKeyServiceServer(srv, s)
+
+	return srv
+}
+
+// authenticate returns a context with the account the call is authenticated
+// with, like the middlewares of the REST routes the service mirrors do. The
+// API keys are managed with an authentication token, so a leaked API key
+// can't be used to create other ones.
+func (app *application) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	var acc *account.Account
+	var err error
+
+	if strings.HasPrefix(fullMethod, "/"+asyncqv1.APIKeyService_ServiceDesc.ServiceName+"/") {
+		acc, err = app.authenticateToken(ctx)
+	} else {
+		acc, err = app.authenticateAPIKey(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !acc.Activated {
+		return nil, status.Error(codes.PermissionDenied, "account must be activated to access this resource")
+	}
+
+	return util.ContextSetAccount(ctx, acc), nil
+}
+
+// authenticateAPIKey returns the account of the API key in the authorization
+// metadata, like the requireAPIKey middleware.
+func (app *application) authenticateAPIKey(ctx context.Context) (*account.Account, error) {
+	plaintext, ok := bearerCredentials(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing API Key")
+	}
+
+	apiKey, err := app.apiKeyService.GetValidAPIKey(ctx, plaintext)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or missing API Key")
+		}
+		return nil, app.grpcServerError(err)
+	}
+
+	acc, err := app.accountService.GetAccount(ctx, apiKey.AccountID)
+	if err != nil {
+		// this should not happen because we were able to get the API Key before
+		return nil, app.grpcServerError(err)
+	}
+
+	return acc, nil
+}
+
+// authenticateToken returns the account of the authentication token in the
+// authorization metadata, like the requireAuthenticatedAccount middleware.
+func (app *application) authenticateToken(ctx context.Context) (*account.Account, error) {
+	plaintext, ok := bearerCredentials(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing authentication token")
+	}
+
+	acc, err := app.accountService.GetForToken(ctx, plaintext, token.ScopeAuthentication)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or missing authentication token")
+		}
+		return nil, app.grpcServerError(err)
+	}
+
+	return acc, nil
+}
+
+// bearerCredentials returns the credentials of the "Bearer <credentials>" authorization metadata.
+func bearerCredentials(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+
+	headerParts := strings.Split(values[0], " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" || headerParts[1] == "" {
+		return "", false
+	}
+
+	return headerParts[1], true
+}
+
+func (app *application) authenticateUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := app.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (app *application) authenticateStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := app.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream is a stream whose context has the authenticated account.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (app *application) recoverPanicUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = app.grpcServerError(fmt.Errorf("%s: %v", info.FullMethod, p))
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (app *application) recoverPanicStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = app.grpcServerError(fmt.Errorf("%s: %v", info.FullMethod, p))
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// grpcError converts the errors of the services to a status, the way the REST
+// handlers convert them to a response.
+func (app *application) grpcError(err error) error {
+	var validationError *validator.ValidationError
+	switch {
+	case errors.As(err, &validationError):
+		return grpcValidationError(validationError.Errors)
+	case errors.Is(err, service.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "the requested resource could not be found")
+	case errors.Is(err, service.ErrInvalidStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrAccountInactive):
+		return status.Error(codes.PermissionDenied, "account must be activated to access this resource")
+	default:
+		return app.grpcServerError(err)
+	}
+}
+
+func (app *application) grpcServerError(err error) error {
+	app.logger.Error(err.Error(), "protocol", "grpc")
+	return status.Error(codes.Internal, "the server encountered a problem and could not process your request")
+}
+
+// grpcValidationError returns an InvalidArgument status with the errors of the
+// fields as its details.
+func grpcValidationError(errors map[string]string) error {
+	badRequest := &errdetails.BadRequest{}
+	for field, description := range errors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations,
+			&errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	st, err := status.New(codes.InvalidArgument, "the request failed validation").WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "the request failed validation")
+	}
+	return st.Err()
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
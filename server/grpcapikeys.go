@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+
+	"github.com/ngmmartins/asyncq/internal/apikey"
+	"github.com/ngmmartins/asyncq/internal/util"
+	asyncqv1 "github.com/ngmmartins/asyncq/proto/asyncq/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *grpcServer) CreateAPIKey(ctx context.Context, req *asyncqv1.CreateAPIKeyRequest) (*asyncqv1.APIKey, error) {
+	input := &apikey.CreateRequest{
+		Name:      req.Name,
+		ExpiresAt: fromTimestamp(req.ExpiresAt),
+	}
+
+	acc := util.ContextGetAccount(ctx)
+
+	apiKey, err := s.app.apiKeyService.CreateAPIKey(ctx, acc.ID, input)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return toProtoAPIKey(apiKey), nil
+}
+
+func (s *grpcServer) ListAPIKeys(ctx context.Context, req *asyncqv1.ListAPIKeysRequest) (*asyncqv1.ListAPIKeysResponse, error) {
+	acc := util.ContextGetAccount(ctx)
+
+	apiKeys, err := s.app.apiKeyService.GetAPIKeys(ctx, acc.ID)
+	if err != nil {
+		return nil, s.app.grpcServerError(err)
+	}
+
+	resp := &asyncqv1.ListAPIKeysResponse{ApiKeys: make([]*asyncqv1.APIKey, 0, len(apiKeys))}
+	for _, apiKey := range apiKeys {
+		resp.ApiKeys = append(resp.ApiKeys, toProtoAPIKey(apiKey))
+	}
+
+	return resp, nil
+}
+
+func (s *grpcServer) GetAPIKey(ctx context.Context, req *asyncqv1.GetAPIKeyRequest) (*asyncqv1.APIKey, error) {
+	// we use the accountId to ensure that the user doesn't get a key from other account
+	acc := util.ContextGetAccount(ctx)
+
+	apiKey, err := s.app.apiKeyService.GetAPIKey(ctx, req.Id, acc.ID)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return toProtoAPIKey(apiKey), nil
+}
+
+func (s *grpcServer) DeleteAPIKey(ctx context.Context, req *asyncqv1.DeleteAPIKeyRequest) (*emptypb.Empty, error) {
+	// we use the accountId to ensure that the user doesn't delete a key from other account
+	acc := util.ContextGetAccount(ctx)
+
+	err := s.app.apiKeyService.DeleteAPIKey(ctx, req.Id, acc.ID)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toProtoAPIKey(apiKey *apikey.APIKey) *asyncqv1.APIKey {
+	return &asyncqv1.APIKey{
+		Id:        apiKey.ID,
+		AccountId: apiKey.AccountID,
+		Name:      apiKey.Name,
+		Key:       apiKey.Key,
+		ExpiresAt: toTimestamp(apiKey.ExpiresAt),
+		CreatedAt: timestamppb.New(apiKey.CreatedAt),
+	}
+}
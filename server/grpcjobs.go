@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/pagination"
+	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+	asyncqv1 "github.com/ngmmartins/asyncq/proto/asyncq/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *grpcServer) CreateJob(ctx context.Context, req *asyncqv1.CreateJobRequest) (*asyncqv1.Job, error) {
+	input := &job.CreateRequest{
+		Task:      task.Task(req.Task),
+		Payload:   json.RawMessage(req.Payload),
+		RunAt:     fromTimestamp(req.RunAt),
+		Tags:      req.Tags,
+		Metadata:  req.Metadata,
+		ExpiresAt: fromTimestamp(req.ExpiresAt),
+	}
+	if req.MaxRetries != nil {
+		maxRetries := int(*req.MaxRetries)
+		input.MaxRetries = &maxRetries
+	}
+	if req.RetryDelaySec != nil {
+		retryDelaySec := int(*req.RetryDelaySec)
+		input.RetryDelaySec = &retryDelaySec
+	}
+
+	acc := util.ContextGetAccount(ctx)
+
+	j, err := s.app.jobService.CreateJob(ctx, acc.ID, input)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return toProtoJob(j), nil
+}
+
+func (s *grpcServer) GetJob(ctx context.Context, req *asyncqv1.GetJobRequest) (*asyncqv1.Job, error) {
+	acc := util.ContextGetAccount(ctx)
+
+	j, err := s.app.jobService.GetAccountJob(ctx, acc.ID, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return toProtoJob(j), nil
+}
+
+func (s *grpcServer) SearchJobs(ctx context.Context, req *asyncqv1.SearchJobsRequest) (*asyncqv1.SearchJobsResponse, error) {
+	v := validator.New()
+
+	criteria := readSearchJobsRequest(req, v)
+	if !v.Valid() {
+		return nil, grpcValidationError(v.Errors)
+	}
+
+	acc := util.ContextGetAccount(ctx)
+	criteria.AccountID = acc.ID
+
+	jobs, metadata, err := s.app.jobService.SearchJobs(ctx, criteria)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	resp := &asyncqv1.SearchJobsResponse{
+		Jobs: make([]*asyncqv1.Job, 0, len(jobs)),
+		Metadata: &asyncqv1.PaginationMetadata{
+			CurrentPage:  int32(metadata.CurrentPage),
+			PageSize:     int32(metadata.PageSize),
+			FirstPage:    int32(metadata.FirstPage),
+			LastPage:     int32(metadata.LastPage),
+			TotalRecords: int32(metadata.TotalRecords),
+			NextCursor:   metadata.NextCursor,
+		},
+	}
+	for _, j := range jobs {
+		resp.Jobs = append(resp.Jobs, toProtoJob(j))
+	}
+
+	return resp, nil
+}
+
+func (s *grpcServer) ScheduleJob(ctx context.Context, req *asyncqv1.ScheduleJobRequest) (*emptypb.Empty, error) {
+	v := validator.New()
+	v.CheckRequired(req.RunAt != nil, "run_at")
+	if !v.Valid() {
+		return nil, grpcValidationError(v.Errors)
+	}
+
+	acc := util.ContextGetAccount(ctx)
+
+	err := s.app.jobService.ScheduleJob(ctx, acc.ID, req.Id, req.RunAt.AsTime())
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcServer) CancelJob(ctx context.Context, req *asyncqv1.CancelJobRequest) (*emptypb.Empty, error) {
+	acc := util.ContextGetAccount(ctx)
+
+	err := s.app.jobService.CancelJob(ctx, acc.ID, req.Id)
+	if err != nil {
+		return nil, s.app.grpcError(err)
+	}
+
+	err = s.app.queue.Remove(ctx, req.Id)
+	if err != nil {
+		return nil, s.app.grpcServerError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// WatchJob streams the status changes of a job of the account, like the job
+// events stream of the REST API filtered by job_id, ending once the job reaches
+// a terminal status. A failed job can still be scheduled again, so its stream
+// goes on.
+func (s *grpcServer) WatchJob(req *asyncqv1.WatchJobRequest, stream asyncqv1.JobService_WatchJobServer) error {
+	ctx := stream.Context()
+	acc := util.ContextGetAccount(ctx)
+
+	filter := &event.Filter{AccountID: acc.ID, JobID: req.Id}
+	if req.LastEventId != nil {
+		filter.AfterID = *req.LastEventId
+	}
+
+	// Subscribe before reading the job so a change in between isn't lost.
+	sub, err := s.app.jobService.SubscribeJobEvents(ctx, filter)
+	if err != nil {
+		return s.app.grpcError(err)
+	}
+	defer sub.Close()
+
+	j, err := s.app.jobService.GetAccountJob(ctx, acc.ID, req.Id)
+	if err != nil {
+		return s.app.grpcError(err)
+	}
+
+	terminal := job.IsTerminal(j.Status)
+
+	if filter.AfterID > 0 {
+		for {
+			events, err := s.app.jobService.ListJobEvents(ctx, filter, eventsReplayBatchSize)
+			if err != nil {
+				return s.app.grpcServerError(err)
+			}
+
+			for _, e := range events {
+				err = stream.Send(toProtoJobEvent(e))
+				if err != nil {
+					return err
+				}
+				filter.AfterID = e.ID
+			}
+
+			if len(events) < eventsReplayBatchSize {
+				break
+			}
+		}
+	}
+
+	// the events of a finished job were all replayed, or the client doesn't want them
+	if terminal {
+		return nil
+	}
+
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			if !filter.Matches(e) {
+				continue
+			}
+
+			// like the REST stream, AfterID stays at the last replayed event
+			// because live events can arrive out of order
+			err = stream.Send(toProtoJobEvent(e))
+			if err != nil {
+				return err
+			}
+
+			if job.IsTerminal(e.Status) {
+				return nil
+			}
+
+		case <-s.app.shutdown:
+			return nil
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func readSearchJobsRequest(req *asyncqv1.SearchJobsRequest, v *validator.Validator) *job.SearchCriteria {
+	criteria := &job.SearchCriteria{
+		Task:            task.Task(req.Task),
+		RunBefore:       fromTimestamp(req.RunBefore),
+		RunAfter:        fromTimestamp(req.RunAfter),
+		CreatedBefore:   fromTimestamp(req.CreatedBefore),
+		CreatedAfter:    fromTimestamp(req.CreatedAfter),
+		FinishedBefore:  fromTimestamp(req.FinishedBefore),
+		FinishedAfter:   fromTimestamp(req.FinishedAfter),
+		HasError:        req.HasError,
+		Query:           req.Q,
+		HeartbeatBefore: fromTimestamp(req.HeartbeatBefore),
+		Tags:            req.Tags,
+		Metadata:        req.Metadata,
+	}
+
+	for _, status := range req.Statuses {
+		criteria.Statuses = append(criteria.Statuses, job.Status(status))
+	}
+
+	if req.RetriesGte != nil {
+		retries := int(*req.RetriesGte)
+		criteria.RetriesGTE = &retries
+	}
+
+	criteria.Page = int(req.Page)
+	if criteria.Page == 0 {
+		criteria.Page = 1
+	}
+	criteria.PageSize = int(req.PageSize)
+	if criteria.PageSize == 0 {
+		criteria.PageSize = 20
+	}
+	criteria.SortBy = req.SortBy
+	if criteria.SortBy == "" {
+		criteria.SortBy = "-created_at"
+	}
+
+	if req.Cursor != nil {
+		cursor, err := pagination.DecodeCursor(*req.Cursor)
+		if err != nil {
+			v.AddError("cursor", "invalid cursor")
+		}
+		criteria.Cursor = cursor
+	}
+
+	return criteria
+}
+
+func toProtoJob(j *job.Job) *asyncqv1.Job {
+	pj := &asyncqv1.Job{
+		Id:             j.ID,
+		AccountId:      j.AccountID,
+		Task:           string(j.Task),
+		Payload:        j.Payload,
+		RunAt:          toTimestamp(j.RunAt),
+		Status:         string(j.Status),
+		CreatedAt:      timestamppb.New(j.CreatedAt),
+		FinishedAt:     toTimestamp(j.FinishedAt),
+		Retries:        int32(j.Retries),
+		MaxRetries:     int32(j.MaxRetries),
+		RetryDelaySec:  int32(j.RetryDelaySec),
+		LastError:      j.LastError,
+		Tags:           j.Tags,
+		Metadata:       j.Metadata,
+		ExpiresAt:      toTimestamp(j.ExpiresAt),
+		HeartbeatAt:    toTimestamp(j.HeartbeatAt),
+		LastErrorCode:  j.LastErrorCode,
+		LeaseExpiresAt: toTimestamp(j.LeaseExpiresAt),
+	}
+
+	if j.Progress != nil {
+		pj.Progress = &asyncqv1.Progress{
+			Percent:   int32(j.Progress.Percent),
+			Message:   j.Progress.Message,
+			UpdatedAt: timestamppb.New(j.Progress.UpdatedAt),
+		}
+	}
+
+	return pj
+}
+
+func toProtoJobEvent(e *event.Event) *asyncqv1.JobEvent {
+	return &asyncqv1.JobEvent{
+		Id:             e.ID,
+		JobId:          e.JobID,
+		Task:           string(e.Task),
+		Status:         string(e.Status),
+		PreviousStatus: string(e.PreviousStatus),
+		CreatedAt:      timestamppb.New(e.CreatedAt),
+	}
+}
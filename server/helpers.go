@@ -1,4 +1,4 @@
-package main
+package server
 
 import (
 	"encoding/json"
@@ -131,12 +131,48 @@ func (app *application) readTime(qs url.Values, key string, v *validator.Validat
 		return &t
 	}
 
+	// a "+" in an unencoded timezone offset is decoded as a space
 	if strings.Contains(s, " ") {
 		cleaned := strings.Replace(s, " ", "+", 1)
-		if t, err = time.Parse(time.RFC3339, cleaned); err != nil {
-			v.AddError("run_before", "invalid time format")
+		if t, err = time.Parse(time.RFC3339, cleaned); err == nil {
+			return &t
 		}
 	}
 
-	return &t
+	v.AddError(key, "invalid time format")
+
+	return nil
+}
+
+func (app *application) readBool(qs url.Values, key string, v *validator.Validator) *bool {
+	s := qs.Get(key)
+
+	if s == "" {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddError(key, "must be a boolean value")
+		return nil
+	}
+
+	return &b
+}
+
+// readList returns the values of a key that can be repeated and/or contain
+// comma separated values, e.g. "status=Done,Failed&status=Cancelled".
+func (app *application) readList(qs url.Values, key string) []string {
+	var list []string
+
+	for _, value := range qs[key] {
+		for item := range strings.SplitSeq(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
 }
@@ -1,14 +1,17 @@
-package main
+package server
 
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/pagination"
 	"github.com/ngmmartins/asyncq/internal/service"
 	"github.com/ngmmartins/asyncq/internal/task"
+	"github.com/ngmmartins/asyncq/internal/util"
 	"github.com/ngmmartins/asyncq/internal/validator"
 )
 
@@ -21,7 +24,12 @@ func (app *application) createJobHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	job, err := app.jobService.CreateJob(r.Context(), &input)
+	// retrying a request with the same key returns the job it created
+	input.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	job, err := app.jobService.CreateJob(r.Context(), acc.ID, &input)
 	if err != nil {
 		var validationError *validator.ValidationError
 		if errors.As(err, &validationError) {
@@ -67,7 +75,9 @@ func (app *application) searchJobsHandler(w http.ResponseWriter, r *http.Request
 func (app *application) getJobHandler(w http.ResponseWriter, r *http.Request) {
 	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
 
-	j, err := app.jobService.GetJob(r.Context(), id)
+	acc := util.ContextGetAccount(r.Context())
+
+	j, err := app.jobService.GetAccountJob(r.Context(), acc.ID, id)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrRecordNotFound):
@@ -87,7 +97,9 @@ func (app *application) getJobHandler(w http.ResponseWriter, r *http.Request) {
 func (app *application) getJobStatusHandler(w http.ResponseWriter, r *http.Request) {
 	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
 
-	j, err := app.jobService.GetJob(r.Context(), id)
+	acc := util.ContextGetAccount(r.Context())
+
+	j, err := app.jobService.GetAccountJob(r.Context(), acc.ID, id)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrRecordNotFound):
@@ -117,9 +129,14 @@ func (app *application) scheduleJobHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = app.jobService.ScheduleJob(r.Context(), id, input.RunAt)
+	acc := util.ContextGetAccount(r.Context())
+
+	err = app.jobService.ScheduleJob(r.Context(), acc.ID, id, input.RunAt)
 	if err != nil {
+		var validationError *validator.ValidationError
 		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
 		case errors.Is(err, service.ErrRecordNotFound):
 			app.notFoundResponse(w, r)
 		case errors.Is(err, service.ErrInvalidStatusTransition):
@@ -139,7 +156,9 @@ func (app *application) scheduleJobHandler(w http.ResponseWriter, r *http.Reques
 func (app *application) cancelJobHandler(w http.ResponseWriter, r *http.Request) {
 	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
 
-	err := app.jobService.UpdateJobStatus(r.Context(), id, job.StatusCancelled)
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.jobService.CancelJob(r.Context(), acc.ID, id)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrRecordNotFound):
@@ -165,7 +184,10 @@ func (app *application) cancelJobHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func (app *application) readSearchCriteria(r *http.Request, v *validator.Validator) *job.SearchCriteria {
-	criteria := &job.SearchCriteria{}
+	// only the jobs of the authenticated account are searched
+	acc := util.ContextGetAccount(r.Context())
+
+	criteria := &job.SearchCriteria{AccountID: acc.ID}
 
 	queryString := r.URL.Query()
 
@@ -176,15 +198,49 @@ func (app *application) readSearchCriteria(r *http.Request, v *validator.Validat
 
 	criteria.RunBefore = app.readTime(queryString, "run_before", v)
 	criteria.RunAfter = app.readTime(queryString, "run_after", v)
+	criteria.CreatedBefore = app.readTime(queryString, "created_before", v)
+	criteria.CreatedAfter = app.readTime(queryString, "created_after", v)
+	criteria.FinishedBefore = app.readTime(queryString, "finished_before", v)
+	criteria.FinishedAfter = app.readTime(queryString, "finished_after", v)
 
-	status := app.readString(queryString, "status", "")
-	if status != "" {
-		criteria.Status = job.Status(status)
+	for _, status := range app.readList(queryString, "status") {
+		criteria.Statuses = append(criteria.Statuses, job.Status(status))
+	}
+
+	if queryString.Has("retries_gte") {
+		retries := app.readInt(queryString, "retries_gte", 0, v)
+		criteria.RetriesGTE = &retries
+	}
+
+	criteria.HasError = app.readBool(queryString, "has_error", v)
+	criteria.Query = app.readString(queryString, "q", "")
+	criteria.HeartbeatBefore = app.readTime(queryString, "heartbeat_before", v)
+
+	// tag can be repeated, in which case jobs must have all of them
+	criteria.Tags = queryString["tag"]
+
+	for key, values := range queryString {
+		if metadataKey, ok := strings.CutPrefix(key, "metadata."); ok {
+			if criteria.Metadata == nil {
+				criteria.Metadata = map[string]string{}
+			}
+			criteria.Metadata[metadataKey] = values[0]
+		}
 	}
 
 	criteria.Page = app.readInt(queryString, "page", 1, v)
 	criteria.PageSize = app.readInt(queryString, "page_size", 20, v)
 	criteria.SortBy = app.readString(queryString, "sort_by", "-created_at")
 
+	// An empty cursor starts keyset pagination from the first page, the following
+	// pages are requested with the next_cursor returned in the metadata.
+	if queryString.Has("cursor") {
+		cursor, err := pagination.DecodeCursor(queryString.Get("cursor"))
+		if err != nil {
+			v.AddError("cursor", "invalid cursor")
+		}
+		criteria.Cursor = cursor
+	}
+
 	return criteria
 }
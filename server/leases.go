@@ -0,0 +1,132 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/ngmmartins/asyncq/internal/job"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+func (app *application) leaseJobsHandler(w http.ResponseWriter, r *http.Request) {
+	var input job.LeaseRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	lease, err := app.jobService.LeaseExternalJobs(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"lease": lease}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) heartbeatJobHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	var input job.LeaseHeartbeatRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	expiresAt, err := app.jobService.HeartbeatLeasedJob(r.Context(), acc.ID, id, &input)
+	if err != nil {
+		app.leasedJobErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"lease_expires_at": expiresAt}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) completeJobHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	var input job.LeaseCompleteRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	err = app.jobService.CompleteLeasedJob(r.Context(), acc.ID, id, &input)
+	if err != nil {
+		app.leasedJobErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) failJobHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	var input job.LeaseFailRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	err = app.jobService.FailLeasedJob(r.Context(), acc.ID, id, &input)
+	if err != nil {
+		app.leasedJobErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// leasedJobErrorResponse responds to the errors of reporting on a leased job. A
+// lost lease, e.g. because it expired and the job was retried, is a conflict.
+func (app *application) leasedJobErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var validationError *validator.ValidationError
+	switch {
+	case errors.As(err, &validationError):
+		app.failedValidationResponse(w, r, validationError.Errors)
+	case errors.Is(err, service.ErrRecordNotFound):
+		app.notFoundResponse(w, r)
+	case errors.Is(err, service.ErrLeaseLost):
+		app.conflictResponse(w, r, map[string]string{"message": err.Error()})
+	default:
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (app *application) routes() http.Handler {
+	router := httprouter.New()
+	router.NotFound = http.HandlerFunc(app.notFoundResponse)
+	router.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
+
+	// Public routes
+	router.HandlerFunc(http.MethodGet, "/v1/health", app.healthHandler)
+
+	router.HandlerFunc(http.MethodPost, "/v1/tokens/authentication", app.createAuthenticationTokenHandler)
+
+	// Protected routes - authentication required
+	router.Handler(http.MethodPost, "/v1/api-keys", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.createAPIKeyHandler))))
+	router.Handler(http.MethodGet, "/v1/api-keys", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getAPIKeysHandler))))
+	router.Handler(http.MethodGet, "/v1/api-keys/:id", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getAPIKeyHandler))))
+	router.Handler(http.MethodDelete, "/v1/api-keys/:id", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteAPIKeyHandler))))
+
+	router.Handler(http.MethodGet, "/v1/account/retention", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getJobRetentionHandler))))
+	router.Handler(http.MethodPut, "/v1/account/retention", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.updateJobRetentionHandler))))
+	router.Handler(http.MethodPost, "/v1/account/signing-secrets", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.rotateSigningSecretHandler))))
+	router.Handler(http.MethodGet, "/v1/account/signing-secrets", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSigningSecretsHandler))))
+	router.Handler(http.MethodDelete, "/v1/account/signing-secrets/:id", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteSigningSecretHandler))))
+	router.Handler(http.MethodGet, "/v1/account/secrets", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSecretsHandler))))
+	router.Handler(http.MethodPut, "/v1/account/secrets/:name", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.putSecretHandler))))
+	router.Handler(http.MethodDelete, "/v1/account/secrets/:name", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteSecretHandler))))
+	router.Handler(http.MethodGet, "/v1/account/smtp", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSMTPConfigHandler))))
+	router.Handler(http.MethodPut, "/v1/account/smtp", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.putSMTPConfigHandler))))
+	router.Handler(http.MethodDelete, "/v1/account/smtp", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteSMTPConfigHandler))))
+	router.Handler(http.MethodPost, "/v1/account/sender-identities", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.createSenderIdentityHandler))))
+	router.Handler(http.MethodGet, "/v1/account/sender-identities", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSenderIdentitiesHandler))))
+	router.Handler(http.MethodGet, "/v1/account/sender-identities/:id", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSenderIdentityHandler))))
+	router.Handler(http.MethodPost, "/v1/account/sender-identities/:id/verify", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.verifySenderIdentityHandler))))
+	router.Handler(http.MethodDelete, "/v1/account/sender-identities/:id", app.requireAuthenticatedAccount(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteSenderIdentityHandler))))
+
+	// Protected routes - API-Key required
+	router.Handler(http.MethodGet, "/v1/tasks", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getTasksHandler))))
+
+	router.Handler(http.MethodPost, "/v1/jobs", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.createJobHandler))))
+	router.Handler(http.MethodGet, "/v1/jobs", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.searchJobsHandler))))
+	router.Handler(http.MethodGet, "/v1/jobs/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getJobHandler))))
+	router.Handler(http.MethodPost, "/v1/jobs/:id/schedule", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.scheduleJobHandler))))
+	router.Handler(http.MethodGet, "/v1/jobs/:id/status", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getJobStatusHandler))))
+	router.Handler(http.MethodPost, "/v1/jobs/:id/cancel", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.cancelJobHandler))))
+	router.Handler(http.MethodPost, "/v1/jobs/:id/heartbeat", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.heartbeatJobHandler))))
+	router.Handler(http.MethodPost, "/v1/jobs/:id/complete", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.completeJobHandler))))
+	router.Handler(http.MethodPost, "/v1/jobs/:id/fail", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.failJobHandler))))
+
+	router.Handler(http.MethodPost, "/v1/workers/lease", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.leaseJobsHandler))))
+
+	router.Handler(http.MethodPost, "/v1/email-templates", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.createEmailTemplateHandler))))
+	router.Handler(http.MethodGet, "/v1/email-templates", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getEmailTemplatesHandler))))
+	router.Handler(http.MethodGet, "/v1/email-templates/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getEmailTemplateHandler))))
+	router.Handler(http.MethodPut, "/v1/email-templates/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.updateEmailTemplateHandler))))
+	router.Handler(http.MethodDelete, "/v1/email-templates/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteEmailTemplateHandler))))
+
+	router.Handler(http.MethodPost, "/v1/blobs", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.uploadBlobHandler))))
+	router.Handler(http.MethodGet, "/v1/blobs/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getBlobHandler))))
+	router.Handler(http.MethodDelete, "/v1/blobs/:id", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteBlobHandler))))
+
+	router.Handler(http.MethodPost, "/v1/email-suppressions", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.createSuppressionHandler))))
+	router.Handler(http.MethodGet, "/v1/email-suppressions", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSuppressionsHandler))))
+	router.Handler(http.MethodGet, "/v1/email-suppressions/:address", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.getSuppressionHandler))))
+	router.Handler(http.MethodDelete, "/v1/email-suppressions/:address", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.deleteSuppressionHandler))))
+	router.Handler(http.MethodPost, "/v1/email-notifications", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.ingestEmailNotificationHandler))))
+
+	// httprouter doesn't allow a static segment next to the :id wildcard, so the
+	// routes under /v1/jobs/ that aren't a job id are matched before reaching it.
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/jobs/events", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.jobEventsHandler))))
+	mux.Handle("GET /v1/jobs/export", app.requireAPIKey(
+		app.requireActivatedAccount(http.HandlerFunc(app.exportJobsHandler))))
+	mux.Handle("/", router)
+
+	return app.recoverPanic(app.enableCORS(app.logRequest(mux)))
+}
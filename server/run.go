@@ -0,0 +1,160 @@
+// Package server runs the asyncq REST and gRPC APIs, see [Run].
+package server
+
+import (
+	"flag"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/bootstrap"
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/queue"
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/store"
+	"github.com/ngmmartins/asyncq/internal/store/postgres"
+	"github.com/ngmmartins/asyncq/internal/util"
+)
+
+type config struct {
+	port     int
+	grpcPort int
+	env      string
+	logLevel slog.Leveler
+	redis    struct {
+		url string
+	}
+	db   postgres.PostgresConfig
+	cors struct {
+		trustedOrigins []string
+	}
+	secretsKey string
+	blobDir    string
+}
+
+type application struct {
+	config                config
+	logger                *slog.Logger
+	queue                 queue.Queue
+	store                 store.Store
+	jobService            *service.JobService
+	tokenService          *service.TokenService
+	accountService        *service.AccountService
+	apiKeyService         *service.APIKeyService
+	signingSecretService  *service.SigningSecretService
+	secretService         *service.SecretService
+	emailTemplateService  *service.EmailTemplateService
+	blobService           *service.BlobService
+	senderIdentityService *service.SenderIdentityService
+	smtpConfigService     *service.SMTPConfigService
+	suppressionService    *service.SuppressionService
+	wg                    sync.WaitGroup
+	// closed when the server starts shutting down so long-lived streams can end
+	shutdown chan struct{}
+}
+
+// Run configures the API from the command-line flags, e.g. -port and -db-dsn,
+// and serves it until the process gets SIGINT or SIGTERM, exiting on errors.
+// It's what cmd/api runs. A module with tasks of its own builds its API binary
+// by calling it from a main package importing the package that registers them,
+// see [github.com/ngmmartins/asyncq/registry].
+func Run() {
+	var cfg config
+	parseFlags(&cfg)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.logLevel}))
+
+	// without a key, the account secrets and the features using them are disabled
+	var cipher *secret.Cipher
+	if cfg.secretsKey != "" {
+		var err error
+		cipher, err = secret.NewCipher(cfg.secretsKey)
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+	} else {
+		logger.Warn("no secrets key configured, account secrets, SMTP configs, signing secrets and webhook auth are disabled")
+	}
+
+	blobs, err := blob.NewFileStore(cfg.blobDir)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	redis := bootstrap.NewRedisClient(logger, cfg.redis.url)
+	store := postgres.New(&cfg.db, logger)
+	queue := queue.NewRedisQueue(logger, redis)
+	broker := event.NewRedisBroker(logger, redis)
+	jobService := service.NewJobService(logger, queue, store, broker, cipher != nil)
+	tokenService := service.NewTokenService(logger, store)
+	accountService := service.NewAccountService(logger, store)
+	apiKeyService := service.NewAPIKeyService(logger, store)
+	signingSecretService := service.NewSigningSecretService(logger, store, cipher)
+	secretService := service.NewSecretService(logger, store, cipher)
+	emailTemplateService := service.NewEmailTemplateService(logger, store)
+	blobService := service.NewBlobService(logger, store, blobs)
+	senderIdentityService := service.NewSenderIdentityService(logger, store, net.DefaultResolver)
+	smtpConfigService := service.NewSMTPConfigService(logger, store, cipher)
+	suppressionService := service.NewSuppressionService(logger, store)
+
+	app := &application{
+		config:                cfg,
+		logger:                logger,
+		queue:                 queue,
+		store:                 store,
+		jobService:            jobService,
+		tokenService:          tokenService,
+		accountService:        accountService,
+		apiKeyService:         apiKeyService,
+		signingSecretService:  signingSecretService,
+		secretService:         secretService,
+		emailTemplateService:  emailTemplateService,
+		blobService:           blobService,
+		senderIdentityService: senderIdentityService,
+		smtpConfigService:     smtpConfigService,
+		suppressionService:    suppressionService,
+		shutdown:              make(chan struct{}),
+	}
+
+	err = app.serve()
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func parseFlags(cfg *config) {
+	flag.IntVar(&cfg.port, "port", 4040, "API server port")
+	flag.IntVar(&cfg.grpcPort, "grpc-port", 4041, "gRPC API server port (0 disables it)")
+	flag.StringVar(&cfg.env, "env", "development", "Environment (development|staging|production)")
+
+	var logLevel string
+	flag.StringVar(&logLevel, "log-level", "Info", "Log level (Debug|Info|Warn|Error)")
+
+	flag.StringVar(&cfg.redis.url, "redis-url", "", "Redis URL")
+
+	flag.StringVar(&cfg.db.DSN, "db-dsn", "", "PostgreSQL DSN")
+	flag.IntVar(&cfg.db.MaxOpenConns, "db-max-open-conns", 25, "PostgreSQL max open connections")
+	flag.IntVar(&cfg.db.MaxIdleConns, "db-max-idle-conns", 25, "PostgreSQL max idle connections")
+	flag.DurationVar(&cfg.db.MaxIdleTime, "db-max-idle-time", 15*time.Minute, "PostgreSQL max connection idle time")
+
+	flag.StringVar(&cfg.secretsKey, "secrets-key", "", "Base64 encoded 32 byte key account secrets are encrypted with (secrets are disabled if empty)")
+
+	flag.StringVar(&cfg.blobDir, "blob-dir", "./data/blobs", "Directory the content of uploaded blobs is stored in")
+
+	flag.Func("cors-trusted-origins", "Trusted CORS origins (space separated)", func(val string) error {
+		cfg.cors.trustedOrigins = strings.Fields(val)
+		return nil
+	})
+
+	flag.Parse()
+
+	cfg.logLevel = util.ParseLogLevel(logLevel)
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/ngmmartins/asyncq/internal/sender"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// createSenderIdentityHandler creates an unverified identity, returning the
+// verification token to publish in the TXT record of its domain.
+func (app *application) createSenderIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	var input sender.IdentityRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	identity, err := app.senderIdentityService.CreateSenderIdentity(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"sender_identity": identity, "verification_record": identity.VerificationRecord()}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getSenderIdentitiesHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	identities, err := app.senderIdentityService.GetSenderIdentities(r.Context(), acc.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"sender_identities": identities}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getSenderIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	identity, err := app.senderIdentityService.GetSenderIdentity(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"sender_identity": identity, "verification_record": identity.VerificationRecord()}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// verifySenderIdentityHandler checks the verification record of the identity,
+// after which emails can be sent from it.
+func (app *application) verifySenderIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	identity, err := app.senderIdentityService.VerifySenderIdentity(r.Context(), id, acc.ID)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.Is(err, service.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"sender_identity": identity}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteSenderIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	id := httprouter.ParamsFromContext(r.Context()).ByName("id")
+
+	// we use the accountId to ensure that the user doesn't delete an identity from other account
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.senderIdentityService.DeleteSenderIdentity(r.Context(), id, acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// putSMTPConfigHandler sets the SMTP server the account's emails are sent through. The password is never returned.
+func (app *application) putSMTPConfigHandler(w http.ResponseWriter, r *http.Request) {
+	var input sender.SMTPConfigRequest
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	config, err := app.smtpConfigService.PutSMTPConfig(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"smtp": config}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getSMTPConfigHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	config, err := app.smtpConfigService.GetSMTPConfig(r.Context(), acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"smtp": config}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// deleteSMTPConfigHandler makes the account's emails be sent through the default SMTP server again.
+func (app *application) deleteSMTPConfigHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.smtpConfigService.DeleteSMTPConfig(r.Context(), acc.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -1,15 +1,18 @@
-package main
+package server
 
 import (
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 func (app *application) serve() error {
@@ -22,6 +25,32 @@ func (app *application) serve() error {
 		ErrorLog:     slog.NewLogLogger(app.logger.Handler(), slog.LevelError),
 	}
 
+	// Shutdown() doesn't interrupt active connections, so streaming handlers are
+	// told to return on their own or they would hold the shutdown until its deadline.
+	srv.RegisterOnShutdown(func() {
+		close(app.shutdown)
+	})
+
+	// the gRPC API is served on its own port, see grpc.go
+	var grpcSrv *grpc.Server
+	if app.config.grpcPort > 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", app.config.grpcPort))
+		if err != nil {
+			return err
+		}
+
+		grpcSrv = app.newGRPCServer()
+
+		go func() {
+			app.logger.Info("starting gRPC server", "addr", lis.Addr().String())
+
+			err := grpcSrv.Serve(lis)
+			if err != nil {
+				app.logger.Error("gRPC server stopped serving", "err", err.Error())
+			}
+		}()
+	}
+
 	shutdownError := make(chan error)
 
 	// Start a background goroutine.
@@ -53,6 +82,22 @@ func (app *application) serve() error {
 			shutdownError <- err
 		}
 
+		// The streams of the gRPC API end when Shutdown() closes app.shutdown, the
+		// calls still running at the deadline are cancelled.
+		if grpcSrv != nil {
+			stopped := make(chan struct{})
+			go func() {
+				grpcSrv.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcSrv.Stop()
+			}
+		}
+
 		app.logger.Info("completing background tasks", "addr", srv.Addr)
 
 		app.wg.Wait()
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/suppression"
+	"github.com/ngmmartins/asyncq/internal/util"
+	"github.com/ngmmartins/asyncq/internal/validator"
+)
+
+// bounce and complaint notifications are larger than JSON requests, as they
+// may include the email they are about
+const maxNotificationSize = 1 << 20
+
+func (app *application) createSuppressionHandler(w http.ResponseWriter, r *http.Request) {
+	var input suppression.Request
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	sup, err := app.suppressionService.AddSuppression(r.Context(), acc.ID, &input)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"suppression": sup}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getSuppressionsHandler(w http.ResponseWriter, r *http.Request) {
+	acc := util.ContextGetAccount(r.Context())
+
+	suppressions, err := app.suppressionService.GetSuppressions(r.Context(), acc.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"suppressions": suppressions}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getSuppressionHandler(w http.ResponseWriter, r *http.Request) {
+	address := httprouter.ParamsFromContext(r.Context()).ByName("address")
+
+	acc := util.ContextGetAccount(r.Context())
+
+	sup, err := app.suppressionService.GetSuppression(r.Context(), acc.ID, address)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"suppression": sup}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteSuppressionHandler(w http.ResponseWriter, r *http.Request) {
+	address := httprouter.ParamsFromContext(r.Context()).ByName("address")
+
+	// we use the accountId to ensure that the user doesn't delete a suppression from other account
+	acc := util.ContextGetAccount(r.Context())
+
+	err := app.suppressionService.DeleteSuppression(r.Context(), acc.ID, address)
+	if err != nil {
+		if errors.Is(err, service.ErrRecordNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusNoContent, nil, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// ingestEmailNotificationHandler suppresses the addresses of a bounce or
+// complaint notification, posted as is by the email provider. The format is
+// told by the content type of the request.
+func (app *application) ingestEmailNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNotificationSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			app.contentTooLargeResponse(w, r, err)
+			return
+		}
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	acc := util.ContextGetAccount(r.Context())
+
+	suppressions, err := app.suppressionService.IngestNotification(r.Context(), acc.ID, r.Header.Get("Content-Type"), body)
+	if err != nil {
+		var validationError *validator.ValidationError
+		switch {
+		case errors.As(err, &validationError):
+			app.failedValidationResponse(w, r, validationError.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"suppressions": suppressions}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,16 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/ngmmartins/asyncq/registry"
+)
+
+// getTasksHandler lists the tasks jobs can be created with, along with the JSON
+// Schema of their payloads.
+func (app *application) getTasksHandler(w http.ResponseWriter, r *http.Request) {
+	err := app.writeJSON(w, http.StatusOK, envelope{"tasks": registry.Tasks()}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
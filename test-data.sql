@@ -1,2 +1,5 @@
 insert into accounts(id, name, email, password_hash, activated) 
 values('cf9ad883-d799-4a08-9519-985eda140f22', 'Test Account', 'test@example.com', '$2a$12$1Tn4v28KjXUVtPQkqmm48utLkiFh5gb7h8akO6At.UlNLAjc/IOiK', true);
+
+insert into sender_identities(id, account_id, address, verification_token, verified_at, created_at)
+values('3d6f0a2b-8c4e-4f1a-9b7d-2e5c8a1f0b3d', 'cf9ad883-d799-4a08-9519-985eda140f22', 'example.com', 'asyncq-verification=TESTDATA', now(), now());
@@ -0,0 +1,157 @@
+// Package webhook signs the webhooks sent by asyncq and lets receivers verify them.
+//
+// Webhooks of accounts with a signing secret carry three headers:
+//
+//	Asyncq-Webhook-Id:        id of the job the call belongs to, the same between retries
+//	Asyncq-Webhook-Timestamp: unix time, in seconds, the call was signed at
+//	Asyncq-Webhook-Signature: space separated list of v1=<signature>
+//
+// Each signature is the hex encoded HMAC-SHA256, keyed with a signing secret, of
+//
+//	<id>.<timestamp>.<body>
+//
+// where body is the raw request body. There is one signature per active secret,
+// so receivers keep working while a rotated secret is being replaced.
+//
+// To verify a call, receivers compute the signature with their secret and compare
+// it, in constant time, to each v1 signature of the header. Calls whose timestamp
+// is too far from the current time must be rejected, which stops captured calls
+// from being replayed later. As retries keep the id, it can be used to ignore calls
+// that were already processed.
+//
+// [Verifier] does all of the above:
+//
+//	verifier := &webhook.Verifier{Secrets: []string{os.Getenv("ASYNCQ_SIGNING_SECRET")}}
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		body, err := verifier.VerifyRequest(r)
+//		if err != nil {
+//			http.Error(w, "invalid signature", http.StatusUnauthorized)
+//			return
+//		}
+//		...
+//	}
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderID        = "Asyncq-Webhook-Id"
+	HeaderTimestamp = "Asyncq-Webhook-Timestamp"
+	HeaderSignature = "Asyncq-Webhook-Signature"
+
+	signatureVersion = "v1"
+)
+
+// DefaultTolerance is how far the timestamp of a call can be from the current time
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingHeaders      = errors.New("webhook: missing signature headers")
+	ErrInvalidTimestamp    = errors.New("webhook: invalid timestamp")
+	ErrTimestampTolerance  = errors.New("webhook: timestamp outside of the tolerance")
+	ErrNoMatchingSignature = errors.New("webhook: no matching signature")
+)
+
+// Sign returns the v1 signature of a call with the given secret.
+func Sign(secret, id string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.%d.", id, timestamp.Unix())
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders sets the signature headers of a call, signing it with each of the secrets.
+func SetHeaders(header http.Header, secrets []string, id string, timestamp time.Time, body []byte) {
+	signatures := make([]string, len(secrets))
+	for i, secret := range secrets {
+		signatures[i] = Sign(secret, id, timestamp, body)
+	}
+
+	header.Set(HeaderID, id)
+	header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	header.Set(HeaderSignature, strings.Join(signatures, " "))
+}
+
+// Verifier verifies the signature of the calls received.
+type Verifier struct {
+	// Any of the secrets is accepted, so a new secret can be added before a rotation
+	Secrets []string
+	// DefaultTolerance is used when 0
+	Tolerance time.Duration
+	// Returns the current time, time.Now when nil
+	Now func() time.Time
+}
+
+// Verify checks that the call with the given headers and body was signed with
+// one of the secrets within the tolerance.
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	id := header.Get(HeaderID)
+	ts := header.Get(HeaderTimestamp)
+	signatures := header.Get(HeaderSignature)
+	if id == "" || ts == "" || signatures == "" {
+		return ErrMissingHeaders
+	}
+
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	timestamp := time.Unix(seconds, 0)
+
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	tolerance := v.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+
+	if diff := now.Sub(timestamp); diff > tolerance || diff < -tolerance {
+		return ErrTimestampTolerance
+	}
+
+	for _, secret := range v.Secrets {
+		expected := []byte(Sign(secret, id, timestamp, body))
+
+		for _, signature := range strings.Fields(signatures) {
+			if hmac.Equal(expected, []byte(signature)) {
+				return nil
+			}
+		}
+	}
+
+	return ErrNoMatchingSignature
+}
+
+// VerifyRequest reads and verifies the body of the request. The body is
+// returned and also put back in the request for the next handlers.
+func (v *Verifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = v.Verify(r.Header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
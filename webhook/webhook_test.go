@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testTime = time.Unix(1700000000, 0)
+	testBody = []byte(`{"event":"job.done"}`)
+)
+
+// signedHeader returns the headers of a call signed at testTime with each of the secrets.
+func signedHeader(secrets ...string) http.Header {
+	header := http.Header{}
+	SetHeaders(header, secrets, "job-1", testTime, testBody)
+	return header
+}
+
+func newTestVerifier(secrets ...string) *Verifier {
+	return &Verifier{Secrets: secrets, Now: func() time.Time { return testTime }}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	err := newTestVerifier("whsec_a").Verify(signedHeader("whsec_a"), testBody)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+}
+
+func TestVerifyRotatedSecrets(t *testing.T) {
+	tests := []struct {
+		name         string
+		signedWith   []string
+		verifiedWith []string
+		wantErr      error
+	}{
+		{name: "receiver has the old secret", signedWith: []string{"whsec_new", "whsec_old"}, verifiedWith: []string{"whsec_old"}},
+		{name: "receiver has the new secret", signedWith: []string{"whsec_new", "whsec_old"}, verifiedWith: []string{"whsec_new"}},
+		{name: "receiver added the new secret", signedWith: []string{"whsec_old"}, verifiedWith: []string{"whsec_new", "whsec_old"}},
+		{name: "no shared secret", signedWith: []string{"whsec_new", "whsec_old"}, verifiedWith: []string{"whsec_other"}, wantErr: ErrNoMatchingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := signedHeader(tt.signedWith...)
+
+			signatures := strings.Fields(header.Get(HeaderSignature))
+			if len(signatures) != len(tt.signedWith) {
+				t.Fatalf("got %d signatures, want one per secret", len(signatures))
+			}
+			for _, signature := range signatures {
+				if !strings.HasPrefix(signature, "v1=") {
+					t.Errorf("signature %q isn't v1", signature)
+				}
+			}
+
+			err := newTestVerifier(tt.verifiedWith...).Verify(header, testBody)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(header http.Header) []byte
+	}{
+		{name: "body", tamper: func(header http.Header) []byte {
+			return []byte(`{"event":"job.failed"}`)
+		}},
+		{name: "id", tamper: func(header http.Header) []byte {
+			header.Set(HeaderID, "job-2")
+			return testBody
+		}},
+		{name: "timestamp", tamper: func(header http.Header) []byte {
+			header.Set(HeaderTimestamp, strconv.FormatInt(testTime.Unix()+1, 10))
+			return testBody
+		}},
+		{name: "signature", tamper: func(header http.Header) []byte {
+			header.Set(HeaderSignature, Sign("whsec_other", "job-1", testTime, testBody))
+			return testBody
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := signedHeader("whsec_a")
+			body := tt.tamper(header)
+
+			err := newTestVerifier("whsec_a").Verify(header, body)
+			if !errors.Is(err, ErrNoMatchingSignature) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrNoMatchingSignature)
+			}
+		})
+	}
+}
+
+func TestVerifyMissingHeaders(t *testing.T) {
+	for _, name := range []string{HeaderID, HeaderTimestamp, HeaderSignature} {
+		t.Run(name, func(t *testing.T) {
+			header := signedHeader("whsec_a")
+			header.Del(name)
+
+			err := newTestVerifier("whsec_a").Verify(header, testBody)
+			if !errors.Is(err, ErrMissingHeaders) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrMissingHeaders)
+			}
+		})
+	}
+}
+
+func TestVerifyInvalidTimestamp(t *testing.T) {
+	header := signedHeader("whsec_a")
+	header.Set(HeaderTimestamp, "yesterday")
+
+	err := newTestVerifier("whsec_a").Verify(header, testBody)
+	if !errors.Is(err, ErrInvalidTimestamp) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrInvalidTimestamp)
+	}
+}
+
+func TestVerifyTolerance(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		offset    time.Duration
+		wantErr   error
+	}{
+		{name: "within default", offset: DefaultTolerance},
+		{name: "within default in the future", offset: -DefaultTolerance},
+		{name: "past default", offset: DefaultTolerance + time.Second, wantErr: ErrTimestampTolerance},
+		{name: "past default in the future", offset: -DefaultTolerance - time.Second, wantErr: ErrTimestampTolerance},
+		{name: "within custom", tolerance: time.Hour, offset: 30 * time.Minute},
+		{name: "past custom", tolerance: time.Minute, offset: 2 * time.Minute, wantErr: ErrTimestampTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier("whsec_a")
+			v.Tolerance = tt.tolerance
+			v.Now = func() time.Time { return testTime.Add(tt.offset) }
+
+			err := v.Verify(signedHeader("whsec_a"), testBody)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRequestRestoresBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(testBody)))
+	r.Header = signedHeader("whsec_a")
+
+	body, err := newTestVerifier("whsec_a").VerifyRequest(r)
+	if err != nil {
+		t.Fatalf("VerifyRequest() error = %v", err)
+	}
+	if string(body) != string(testBody) {
+		t.Errorf("body = %q, want %q", body, testBody)
+	}
+
+	again, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(testBody) {
+		t.Errorf("request body = %q, want %q", again, testBody)
+	}
+}
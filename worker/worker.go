@@ -0,0 +1,222 @@
+// Package worker runs the asyncq worker, which runs the queued jobs, see [Run].
+package worker
+
+import (
+	"context"
+	"expvar"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ngmmartins/asyncq/internal/blob"
+	"github.com/ngmmartins/asyncq/internal/bootstrap"
+	"github.com/ngmmartins/asyncq/internal/email"
+	"github.com/ngmmartins/asyncq/internal/event"
+	"github.com/ngmmartins/asyncq/internal/queue"
+	"github.com/ngmmartins/asyncq/internal/secret"
+	"github.com/ngmmartins/asyncq/internal/service"
+	"github.com/ngmmartins/asyncq/internal/store/postgres"
+	"github.com/ngmmartins/asyncq/internal/util"
+	jobworker "github.com/ngmmartins/asyncq/internal/worker"
+	"github.com/ngmmartins/asyncq/internal/worker/tasks"
+)
+
+type config struct {
+	env                 string
+	logLevel            slog.Leveler
+	tickInterval        time.Duration
+	heartbeatInterval   time.Duration
+	leaseExpiryInterval time.Duration
+	redis               struct {
+		url string
+	}
+	db          postgres.PostgresConfig
+	email       email.Config
+	retention   jobworker.RetentionConfig
+	webhook     tasks.WebhookConfig
+	secretsKey  string
+	blobDir     string
+	metricsAddr string
+}
+
+// Run configures the worker from the command-line flags, e.g. -tick-interval
+// and -db-dsn, and runs the queued jobs, exiting on errors. It's what cmd/worker
+// runs. A module with tasks of its own builds its worker binary by calling it
+// from a main package importing the package that registers them, see
+// [github.com/ngmmartins/asyncq/registry].
+func Run() {
+	var cfg config
+	parseFlags(&cfg)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.logLevel}))
+
+	validateConfig(logger, &cfg)
+
+	// without a key, the account secrets and the features using them are disabled
+	var cipher *secret.Cipher
+	if cfg.secretsKey != "" {
+		var err error
+		cipher, err = secret.NewCipher(cfg.secretsKey)
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+	} else {
+		logger.Warn("no secrets key configured, account secrets, SMTP configs, signing secrets and webhook auth are disabled")
+	}
+
+	blobs, err := blob.NewFileStore(cfg.blobDir)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	redis := bootstrap.NewRedisClient(logger, cfg.redis.url)
+	store := postgres.New(&cfg.db, logger)
+	queue := queue.NewRedisQueue(logger, redis)
+	broker := event.NewRedisBroker(logger, redis)
+	jobService := service.NewJobService(logger, queue, store, broker, cipher != nil)
+	secretService := service.NewSecretService(logger, store, cipher)
+	signingSecretService := service.NewSigningSecretService(logger, store, cipher)
+	blobService := service.NewBlobService(logger, store, blobs)
+	smtpConfigService := service.NewSMTPConfigService(logger, store, cipher)
+	suppressionService := service.NewSuppressionService(logger, store)
+	emailSender, err := email.New(logger, &cfg.email)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	w := jobworker.New(store, queue, logger, jobService, secretService, signingSecretService, blobService, smtpConfigService, suppressionService, emailSender, cfg.webhook)
+
+	ctx := context.Background()
+
+	if cfg.metricsAddr != "" {
+		go serveMetrics(logger, cfg.metricsAddr)
+	}
+
+	go w.RunHeartbeats(ctx, cfg.heartbeatInterval)
+	go w.RunLeaseExpiry(ctx, cfg.leaseExpiryInterval)
+
+	if cfg.retention.Interval > 0 {
+		janitor := jobworker.NewJanitor(logger, jobService, cfg.retention)
+		go janitor.Run(ctx)
+	}
+
+	logger.Info("worker started", "env", cfg.env)
+	w.Run(ctx, cfg.tickInterval)
+}
+
+// serveMetrics exposes the expvar metrics, e.g. the jobs purged by the janitor, at /debug/vars.
+func serveMetrics(logger *slog.Logger, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /debug/vars", expvar.Handler())
+
+	logger.Info("serving metrics", "addr", addr)
+
+	err := http.ListenAndServe(addr, mux)
+	if err != nil {
+		logger.Error("metrics server stopped", "err", err.Error())
+	}
+}
+
+func parseFlags(cfg *config) {
+	flag.StringVar(&cfg.env, "env", "development", "Environment (development|staging|production)")
+	flag.DurationVar(&cfg.tickInterval, "tick-interval", 2*time.Second, "How frequentlly the worker will poll jobs from queue")
+	flag.DurationVar(&cfg.heartbeatInterval, "heartbeat-interval", 10*time.Second, "How frequently the heartbeat of running jobs is updated")
+	flag.DurationVar(&cfg.leaseExpiryInterval, "lease-expiry-interval", 10*time.Second, "How frequently the external jobs whose lease expired are failed")
+
+	var logLevel string
+	flag.StringVar(&logLevel, "log-level", "Info", "Log level (Debug|Info|Warn|Error)")
+
+	flag.StringVar(&cfg.redis.url, "redis-url", "", "Redis URL")
+
+	flag.StringVar(&cfg.db.DSN, "db-dsn", "", "PostgreSQL DSN")
+	flag.IntVar(&cfg.db.MaxOpenConns, "db-max-open-conns", 25, "PostgreSQL max open connections")
+	flag.IntVar(&cfg.db.MaxIdleConns, "db-max-idle-conns", 25, "PostgreSQL max idle connections")
+	flag.DurationVar(&cfg.db.MaxIdleTime, "db-max-idle-time", 15*time.Minute, "PostgreSQL max connection idle time")
+
+	flag.StringVar(&cfg.email.Provider, "email-provider", "smtp", fmt.Sprintf("Provider emails of accounts without their own SMTP config are sent with (%s)", strings.Join(email.Providers(), "|")))
+
+	flag.StringVar(&cfg.email.SMTP.Host, "smtp-host", "", "SMTP host")
+	flag.IntVar(&cfg.email.SMTP.Port, "smtp-port", 25, "SMTP port")
+	flag.StringVar(&cfg.email.SMTP.Username, "smtp-username", "", "SMTP username")
+	flag.StringVar(&cfg.email.SMTP.Password, "smtp-password", "", "SMTP password")
+	flag.IntVar(&cfg.email.SMTP.MaxIdleConns, "smtp-max-idle-conns", email.DefaultSMTPMaxIdleConns, "SMTP connections kept open between emails")
+
+	flag.StringVar(&cfg.email.HTTP.URL, "email-http-url", "", "URL of the JSON email API of the http provider")
+	flag.StringVar(&cfg.email.HTTP.Token, "email-http-token", "", "Bearer token of the JSON email API of the http provider")
+	flag.DurationVar(&cfg.email.HTTP.Timeout, "email-http-timeout", 10*time.Second, "Timeout of the calls to the JSON email API of the http provider")
+
+	flag.StringVar(&cfg.email.MaildirDir, "email-maildir-dir", "./data/maildir", "Maildir the emails are delivered to by the maildir provider")
+
+	flag.IntVar(&cfg.retention.DefaultDays, "retention-days", 0, "Days finished jobs are kept for when their account doesn't set it (0 keeps them forever)")
+	var retentionMode string
+	flag.StringVar(&retentionMode, "retention-mode", string(jobworker.RetentionModeDelete), "What happens to jobs past their retention (delete|table|files)")
+	flag.StringVar(&cfg.retention.ArchiveDir, "retention-archive-dir", "", "Directory where purged jobs are archived in files mode")
+	flag.DurationVar(&cfg.retention.Interval, "retention-interval", time.Hour, "How frequently jobs past their retention are purged (0 disables it)")
+	flag.IntVar(&cfg.retention.BatchSize, "retention-batch-size", 1000, "How many jobs are purged per transaction")
+
+	flag.Func("webhook-allowed-networks", "Internal networks webhooks and account SMTP servers are allowed to call, in CIDR notation (space separated)", func(val string) error {
+		for _, network := range strings.Fields(val) {
+			prefix, err := netip.ParsePrefix(network)
+			if err != nil {
+				return err
+			}
+			cfg.webhook.AllowedNetworks = append(cfg.webhook.AllowedNetworks, prefix)
+		}
+		return nil
+	})
+
+	flag.StringVar(&cfg.secretsKey, "secrets-key", "", "Base64 encoded 32 byte key account secrets are encrypted with (secrets are disabled if empty)")
+
+	flag.StringVar(&cfg.blobDir, "blob-dir", "./data/blobs", "Directory the content of uploaded blobs is stored in")
+
+	flag.StringVar(&cfg.metricsAddr, "metrics-addr", "", "Address to serve metrics on, e.g. :4041 (disabled if empty)")
+
+	flag.Parse()
+
+	cfg.logLevel = util.ParseLogLevel(logLevel)
+	cfg.retention.Mode = jobworker.RetentionMode(retentionMode)
+}
+
+func validateConfig(logger *slog.Logger, cfg *config) {
+	if cfg.heartbeatInterval <= 0 {
+		logger.Error("heartbeat interval must be greater than 0", "heartbeatInterval", cfg.heartbeatInterval)
+		os.Exit(1)
+	}
+
+	if cfg.leaseExpiryInterval <= 0 {
+		logger.Error("lease expiry interval must be greater than 0", "leaseExpiryInterval", cfg.leaseExpiryInterval)
+		os.Exit(1)
+	}
+
+	if !slices.Contains(jobworker.RetentionModes, cfg.retention.Mode) {
+		logger.Error("invalid retention mode", "mode", cfg.retention.Mode)
+		os.Exit(1)
+	}
+
+	if cfg.retention.BatchSize <= 0 {
+		logger.Error("retention batch size must be greater than 0", "batchSize", cfg.retention.BatchSize)
+		os.Exit(1)
+	}
+
+	if cfg.retention.Mode == jobworker.RetentionModeFiles {
+		if cfg.retention.ArchiveDir == "" {
+			logger.Error("retention archive dir is required in files mode")
+			os.Exit(1)
+		}
+
+		err := os.MkdirAll(cfg.retention.ArchiveDir, 0o750)
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+	}
+}